@@ -0,0 +1,131 @@
+package cmd
+
+import (
+	"fmt"
+
+	"github.com/forkspacer/cli/pkg/batch"
+	"github.com/forkspacer/cli/pkg/printer"
+	"github.com/forkspacer/cli/pkg/styles"
+	"github.com/spf13/cobra"
+	"k8s.io/apimachinery/pkg/labels"
+)
+
+// Selection describes which objects a multi-target command operates on
+type Selection struct {
+	Names       []string
+	Selector    labels.Selector
+	All         bool
+	Concurrency int
+}
+
+// AddSelectionFlags registers -l/--selector, --all and --concurrency on a
+// command that accepts several object names
+func AddSelectionFlags(c *cobra.Command) {
+	c.Flags().StringP("selector", "l", "",
+		"Label selector to filter objects (e.g. team=payments,env!=prod)")
+	c.Flags().Bool("all", false,
+		"Select all objects in the namespace")
+	c.Flags().Int("concurrency", batch.DefaultConcurrency,
+		"Maximum number of objects processed in parallel")
+}
+
+// GetSelection combines positional names with the selection flags of a command
+func GetSelection(c *cobra.Command, args []string) (*Selection, error) {
+	selectorValue, err := c.Flags().GetString("selector")
+	if err != nil {
+		return nil, err
+	}
+	all, err := c.Flags().GetBool("all")
+	if err != nil {
+		return nil, err
+	}
+	concurrency, err := c.Flags().GetInt("concurrency")
+	if err != nil {
+		return nil, err
+	}
+
+	if concurrency < 1 {
+		return nil, fmt.Errorf("--concurrency must be at least 1")
+	}
+	if len(args) > 0 && (all || selectorValue != "") {
+		return nil, fmt.Errorf("names cannot be combined with --all or --selector")
+	}
+	if all && selectorValue != "" {
+		return nil, fmt.Errorf("--all cannot be combined with --selector")
+	}
+	if len(args) == 0 && !all && selectorValue == "" {
+		return nil, fmt.Errorf("specify at least one name, --selector or --all")
+	}
+
+	selection := &Selection{
+		Names:       args,
+		All:         all,
+		Concurrency: concurrency,
+	}
+
+	if selectorValue != "" {
+		selector, err := labels.Parse(selectorValue)
+		if err != nil {
+			return nil, fmt.Errorf("invalid --selector: %w", err)
+		}
+		selection.Selector = selector
+	}
+
+	return selection, nil
+}
+
+// IsSingle reports whether exactly one object was named explicitly
+func (s *Selection) IsSingle() bool {
+	return len(s.Names) == 1
+}
+
+// batchResultRow is the json/yaml representation of a batch result
+type batchResultRow struct {
+	Name      string `json:"name"`
+	Namespace string `json:"namespace"`
+	Status    string `json:"status"`
+	Message   string `json:"message"`
+}
+
+// PrintBatchResults renders the outcome of a multi-target operation and
+// returns an error when any of the operations failed
+func PrintBatchResults(kind string, results []batch.Result) error {
+	rows := make([]batchResultRow, 0, len(results))
+	for _, r := range results {
+		row := batchResultRow{
+			Name:      r.Name,
+			Namespace: r.Namespace,
+			Status:    "ok",
+			Message:   r.Message,
+		}
+		if r.Err != nil {
+			row.Status = "failed"
+			row.Message = r.Err.Error()
+		}
+		rows = append(rows, row)
+	}
+
+	failed := batch.Failed(results)
+
+	if IsStructuredOutput() {
+		if err := printer.PrintObject(rows, output); err != nil {
+			return err
+		}
+	} else {
+		fmt.Println()
+		table := printer.NewTable([]string{"NAME", "NAMESPACE", "STATUS", "MESSAGE"})
+		for _, row := range rows {
+			table.AddRow([]string{row.Name, row.Namespace, row.Status, row.Message})
+		}
+		table.Render()
+		fmt.Println()
+		fmt.Println(styles.MutedStyle.Render(fmt.Sprintf("Total: %d %s(s), %d succeeded, %d failed",
+			len(results), kind, len(results)-failed, failed)))
+		fmt.Println()
+	}
+
+	if failed > 0 {
+		return fmt.Errorf("%d of %d %s operation(s) failed", failed, len(results), kind)
+	}
+	return nil
+}
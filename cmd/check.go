@@ -0,0 +1,119 @@
+package cmd
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	"github.com/spf13/cobra"
+	ctrl "sigs.k8s.io/controller-runtime"
+
+	"github.com/forkspacer/cli/pkg/preflight"
+	"github.com/forkspacer/cli/pkg/printer"
+	"github.com/forkspacer/cli/pkg/styles"
+)
+
+var checkCmd = &cobra.Command{
+	Use:   "check",
+	Short: "Check that the operator and CRDs are installed and compatible",
+	Long: `Check that the cluster is ready to be used with this CLI.
+
+The check discovers the Forkspacer API versions served by the cluster,
+verifies that the Workspace and Module CRDs are installed, finds the
+operator deployment and compares its image tag with the Forkspacer API
+version the CLI was built against.
+
+Examples:
+  # Check the current cluster
+  forkspacer check
+
+  # Machine readable output
+  forkspacer check -o json`,
+	Args: cobra.NoArgs,
+	RunE: runCheck,
+}
+
+func init() {
+	rootCmd.AddCommand(checkCmd)
+}
+
+func runCheck(c *cobra.Command, args []string) error {
+	restConfig, err := ctrl.GetConfig()
+	if err != nil {
+		return fmt.Errorf("failed to connect to cluster: %w", err)
+	}
+
+	report, err := preflight.Run(context.Background(), restConfig)
+	if err != nil {
+		return fmt.Errorf("failed to connect to cluster: %w", err)
+	}
+
+	if IsStructuredOutput() {
+		if err := printer.PrintObject(report, GetOutput()); err != nil {
+			return err
+		}
+	} else {
+		printCheckReport(report, restConfig.Host)
+	}
+
+	if report.HasErrors() {
+		return fmt.Errorf("the cluster is not ready for Forkspacer")
+	}
+	return nil
+}
+
+func printCheckReport(report *preflight.Report, host string) {
+	fmt.Println()
+	fmt.Println(styles.TitleStyle.Render("Forkspacer Check"))
+	fmt.Println()
+
+	fmt.Printf("  %s  %s\n", styles.Key("Cluster:"), styles.Value(host))
+	fmt.Printf("  %s  %s\n", styles.Key("Kubernetes:"), styles.Value(report.KubernetesVersion))
+	fmt.Printf("  %s  %s\n", styles.Key("CLI API Version:"), styles.Value(report.APIVersion))
+	served := "none"
+	if len(report.ServedVersions) > 0 {
+		served = strings.Join(report.ServedVersions, ", ")
+	}
+	fmt.Printf("  %s  %s\n", styles.Key("Served Versions:"), styles.Value(report.Group+" "+served))
+	fmt.Println()
+
+	fmt.Println(styles.KeyStyle.Render("CRDs"))
+	for _, crd := range report.CRDs {
+		name := crd.Resource + "." + report.Group
+		if crd.Installed {
+			fmt.Printf("  %s %s\n", styles.SuccessStyle.Render(styles.SymbolSuccess), name)
+		} else {
+			fmt.Printf("  %s %s %s\n", styles.ErrorStyle.Render(styles.SymbolError), name,
+				styles.MutedStyle.Render("(not installed)"))
+		}
+	}
+	fmt.Println()
+
+	fmt.Println(styles.KeyStyle.Render("Operator"))
+	if operator := report.Operator; operator != nil {
+		fmt.Printf("  %s  %s\n", styles.Key("Deployment:"), styles.Value(operator.Namespace+"/"+operator.Name))
+		fmt.Printf("  %s  %s\n", styles.Key("Image:"), styles.Value(operator.Image))
+		fmt.Printf("  %s  %d/%d\n", styles.Key("Ready:"), operator.ReadyReplicas, operator.Replicas)
+	} else {
+		fmt.Println("  " + styles.MutedStyle.Render("<not found>"))
+	}
+	fmt.Println()
+
+	if len(report.Issues) == 0 {
+		fmt.Println(styles.Success("The operator and CRDs are installed and compatible"))
+		fmt.Println()
+		return
+	}
+
+	for _, issue := range report.Issues {
+		if issue.Severity == preflight.SeverityError {
+			fmt.Println(styles.Error(issue.Message))
+		} else {
+			fmt.Println(styles.Warning(issue.Message))
+		}
+		if issue.Hint != "" {
+			fmt.Printf("  %s %s\n", styles.InfoStyle.Render(styles.SymbolArrow), styles.MutedStyle.Render(issue.Hint))
+		}
+	}
+	fmt.Println()
+}
@@ -0,0 +1,21 @@
+package doctor
+
+import (
+	"github.com/forkspacer/cli/cmd"
+	"github.com/spf13/cobra"
+)
+
+// DoctorCmd represents the doctor command
+var DoctorCmd = &cobra.Command{
+	Use:   "doctor",
+	Short: "Troubleshoot workspaces that are not ready",
+	Long: `Run diagnostic checks against Forkspacer resources and print what is wrong
+together with hints on how to fix it.`,
+}
+
+func init() {
+	cmd.GetRootCmd().AddCommand(DoctorCmd)
+
+	// Add subcommands
+	DoctorCmd.AddCommand(workspaceCmd)
+}
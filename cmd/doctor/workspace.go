@@ -0,0 +1,128 @@
+package doctor
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/spf13/cobra"
+
+	"github.com/forkspacer/cli/cmd"
+	doctorService "github.com/forkspacer/cli/pkg/doctor"
+	moduleService "github.com/forkspacer/cli/pkg/module"
+	"github.com/forkspacer/cli/pkg/printer"
+	"github.com/forkspacer/cli/pkg/styles"
+	workspaceService "github.com/forkspacer/cli/pkg/workspace"
+)
+
+var workspaceCmd = &cobra.Command{
+	Use:   "workspace [name]",
+	Short: "Diagnose a workspace and its modules",
+	Long: `Diagnose a workspace and the modules that reference it.
+
+The following checks are run:
+  References     the fork source and values config maps exist
+  Secrets        connection, chart auth and image pull secrets exist with
+                 the keys the operator reads
+  Helm releases  the release of each Helm module exists and is deployed
+  Pods           the pods of each awake release are running and ready
+  Schedules      the auto-hibernation schedules are valid
+
+Every warning and failure comes with a hint on how to fix it. The command
+exits with an error if any check fails.
+
+Examples:
+  # Diagnose a workspace
+  forkspacer doctor workspace dev-env
+
+  # Machine readable results
+  forkspacer doctor workspace dev-env -o json`,
+	Args: cobra.ExactArgs(1),
+	RunE: runWorkspace,
+}
+
+func runWorkspace(c *cobra.Command, args []string) error {
+	name := args[0]
+	namespace := cmd.GetNamespace()
+
+	ctx := context.Background()
+	wsService, err := workspaceService.NewService()
+	if err != nil {
+		return fmt.Errorf("failed to connect to cluster: %w", err)
+	}
+	modService, err := moduleService.NewService()
+	if err != nil {
+		return fmt.Errorf("failed to connect to cluster: %w", err)
+	}
+	service, err := doctorService.NewService()
+	if err != nil {
+		return fmt.Errorf("failed to connect to cluster: %w", err)
+	}
+
+	workspace, err := wsService.Get(ctx, name, namespace)
+	if err != nil {
+		return err
+	}
+	modules, err := modService.ListByWorkspace(ctx, workspace.Name, workspace.Namespace)
+	if err != nil {
+		return fmt.Errorf("failed to list modules: %w", err)
+	}
+
+	target := &doctorService.Target{Workspace: workspace, Modules: modules}
+	report := service.Diagnose(ctx, target, doctorService.WorkspaceChecks())
+
+	if cmd.IsStructuredOutput() {
+		if err := printer.PrintObject(report, cmd.GetOutput()); err != nil {
+			return err
+		}
+	} else {
+		printReport(report)
+	}
+
+	if failed := report.Count(doctorService.StatusFail); failed > 0 {
+		return fmt.Errorf("%d check(s) failed", failed)
+	}
+	return nil
+}
+
+// printReport prints the results grouped by check
+func printReport(report *doctorService.Report) {
+	fmt.Println()
+	fmt.Println(styles.TitleStyle.Render(fmt.Sprintf("Doctor: %s/%s", report.Namespace, report.Workspace)))
+	fmt.Println()
+
+	check := ""
+	for _, result := range report.Results {
+		if result.Check != check {
+			if check != "" {
+				fmt.Println()
+			}
+			check = result.Check
+			fmt.Println(styles.KeyStyle.Render(check))
+		}
+
+		fmt.Printf("  %s %s %s\n", statusSymbol(result.Status),
+			styles.MutedStyle.Render(result.Subject+":"), result.Message)
+		if result.Hint != "" && result.Status != doctorService.StatusPass {
+			fmt.Printf("      %s %s\n", styles.InfoStyle.Render(styles.SymbolArrow), styles.MutedStyle.Render(result.Hint))
+		}
+	}
+	fmt.Println()
+
+	fmt.Println(styles.Divider())
+	fmt.Printf("%s passed, %s warnings, %s failed\n",
+		styles.SuccessStyle.Render(fmt.Sprint(report.Count(doctorService.StatusPass))),
+		styles.WarningStyle.Render(fmt.Sprint(report.Count(doctorService.StatusWarn))),
+		styles.ErrorStyle.Render(fmt.Sprint(report.Count(doctorService.StatusFail))))
+	fmt.Println()
+}
+
+func statusSymbol(status doctorService.Status) string {
+	switch status {
+	case doctorService.StatusPass:
+		return styles.SuccessStyle.Render(styles.SymbolSuccess)
+	case doctorService.StatusWarn:
+		return styles.WarningStyle.Render(styles.SymbolWarning)
+	default:
+		return styles.ErrorStyle.Render(styles.SymbolError)
+	}
+}
@@ -0,0 +1,59 @@
+package cmd
+
+import (
+	"fmt"
+
+	"github.com/forkspacer/cli/pkg/printer"
+	"github.com/forkspacer/cli/pkg/styles"
+	"github.com/spf13/cobra"
+)
+
+// DryRunStrategy controls whether a mutating command persists its changes
+type DryRunStrategy string
+
+const (
+	// DryRunNone performs the operation normally
+	DryRunNone DryRunStrategy = "none"
+	// DryRunClient builds the object locally without sending it to the cluster
+	DryRunClient DryRunStrategy = "client"
+	// DryRunServer submits the request with DryRun: All so admission and
+	// validation run without persisting anything
+	DryRunServer DryRunStrategy = "server"
+)
+
+// AddDryRunFlag registers the --dry-run flag on a mutating command
+func AddDryRunFlag(c *cobra.Command) {
+	c.Flags().String("dry-run", string(DryRunNone),
+		"Preview the change without persisting it (none|client|server)")
+	c.RegisterFlagCompletionFunc("dry-run", func(*cobra.Command, []string, string) ([]string, cobra.ShellCompDirective) {
+		return []string{string(DryRunNone), string(DryRunClient), string(DryRunServer)}, cobra.ShellCompDirectiveNoFileComp
+	})
+}
+
+// GetDryRunStrategy returns the validated --dry-run value of a command
+func GetDryRunStrategy(c *cobra.Command) (DryRunStrategy, error) {
+	value, err := c.Flags().GetString("dry-run")
+	if err != nil {
+		return DryRunNone, err
+	}
+
+	switch strategy := DryRunStrategy(value); strategy {
+	case DryRunNone, DryRunClient, DryRunServer:
+		return strategy, nil
+	default:
+		return DryRunNone, fmt.Errorf("invalid --dry-run value %q (expected none, client or server)", value)
+	}
+}
+
+// PrintDryRunResult prints the object produced by a dry-run operation.
+// Table output prints a one-line summary, json and yaml print the full object.
+func PrintDryRunResult(obj interface{}, summary string, strategy DryRunStrategy) error {
+	if !IsStructuredOutput() {
+		fmt.Println()
+		fmt.Println(styles.Info(fmt.Sprintf("%s (%s dry run)", summary, strategy)))
+		fmt.Println()
+		return nil
+	}
+
+	return printer.PrintObject(obj, output)
+}
@@ -7,6 +7,7 @@ import (
 
 	batchv1 "github.com/forkspacer/forkspacer/api/v1"
 	"github.com/spf13/cobra"
+	"sigs.k8s.io/controller-runtime/pkg/client"
 
 	"github.com/forkspacer/cli/cmd"
 	"github.com/forkspacer/cli/pkg/module"
@@ -65,7 +66,15 @@ Examples:
     --workspace dev-env \
     --chart-git-repo https://github.com/org/repo \
     --chart-git-path charts/app \
-    --hibernated`,
+    --hibernated
+
+  # Preview the module object without creating it
+  forkspacer module add my-module \
+    --helm-release my-release \
+    --workspace dev-env \
+    --chart-git-repo https://github.com/org/repo \
+    --chart-git-path charts/app \
+    --dry-run=client -o yaml`,
 	Args: validateAddArgs,
 	RunE: runAdd,
 }
@@ -110,6 +119,7 @@ func init() {
 	addCmd.MarkFlagRequired("workspace")
 	addCmd.MarkFlagRequired("chart-git-repo")
 	addCmd.MarkFlagRequired("chart-git-path")
+	cmd.AddDryRunFlag(addCmd)
 
 	moduleCmd.AddCommand(addCmd)
 }
@@ -128,6 +138,14 @@ func runAdd(c *cobra.Command, args []string) error {
 		addChartGitAuthSecretNS = namespace
 	}
 
+	dryRun, err := cmd.GetDryRunStrategy(c)
+	if err != nil {
+		return err
+	}
+	if dryRun != cmd.DryRunNone {
+		return runAddDryRun(name, namespace, dryRun)
+	}
+
 	// Print header
 	fmt.Println()
 	fmt.Println(styles.TitleStyle.Render(fmt.Sprintf("%s Adding module %s", styles.SymbolSparkles, name)))
@@ -210,6 +228,64 @@ func runAdd(c *cobra.Command, args []string) error {
 	return nil
 }
 
+// runAddDryRun validates the names and builds the module without persisting
+// it. Server mode submits it with DryRun: All so admission webhooks and CRD
+// validation still run.
+func runAddDryRun(name, namespace string, dryRun cmd.DryRunStrategy) error {
+	if err := validation.ValidateDNS1123Subdomain(name); err != nil {
+		return formatAddValidationError(name, err)
+	}
+	if err := validation.ValidateDNS1123Subdomain(addHelmRelease); err != nil {
+		return formatAddValidationError(addHelmRelease, err)
+	}
+
+	var moduleResource *batchv1.Module
+	if dryRun == cmd.DryRunServer {
+		ctx := context.Background()
+		service, err := module.NewService()
+		if err != nil {
+			return fmt.Errorf("kubernetes connection failed: %w", err)
+		}
+
+		moduleResource, err = service.CreateExistingHelmRelease(
+			ctx,
+			name,
+			namespace,
+			addHelmRelease,
+			addHelmReleaseNamespace,
+			addWorkspace,
+			addWorkspaceNamespace,
+			addHibernated,
+			addChartGitRepo,
+			addChartGitPath,
+			addChartGitRevision,
+			addChartGitAuthSecret,
+			addChartGitAuthSecretNS,
+			client.DryRunAll,
+		)
+		if err != nil {
+			return fmt.Errorf("failed to create module: %w", err)
+		}
+	} else {
+		moduleResource = module.BuildExistingHelmRelease(
+			name,
+			namespace,
+			addHelmRelease,
+			addHelmReleaseNamespace,
+			addWorkspace,
+			addWorkspaceNamespace,
+			addHibernated,
+			addChartGitRepo,
+			addChartGitPath,
+			addChartGitRevision,
+			addChartGitAuthSecret,
+			addChartGitAuthSecretNS,
+		)
+	}
+
+	return cmd.PrintDryRunResult(moduleResource, fmt.Sprintf("module/%s created", name), dryRun)
+}
+
 func waitForModuleReady(ctx context.Context, service *module.Service, name, namespace string, timeout time.Duration) error {
 	ticker := time.NewTicker(2 * time.Second)
 	defer ticker.Stop()
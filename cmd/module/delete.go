@@ -5,13 +5,19 @@ import (
 	"fmt"
 
 	"github.com/forkspacer/cli/cmd"
+	"github.com/forkspacer/cli/pkg/batch"
 	"github.com/forkspacer/cli/pkg/module"
 	"github.com/forkspacer/cli/pkg/styles"
 	"github.com/spf13/cobra"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+)
+
+var (
+	deleteForce bool
 )
 
 var deleteCmd = &cobra.Command{
-	Use:   "delete [name]",
+	Use:   "delete [name...]",
 	Short: "Delete a module",
 	Long: `Delete a Forkspacer module.
 
@@ -22,25 +28,65 @@ Examples:
   forkspacer module delete my-module
 
   # Delete module in specific namespace
-  forkspacer module delete my-module -n production`,
-	Args: cobra.ExactArgs(1),
+  forkspacer module delete my-module -n production
+
+  # Delete several modules at once
+  forkspacer module delete redis postgres
+
+  # Delete all modules matching a label selector without confirmation
+  forkspacer module delete -l app=cache --force
+
+  # Check that the deletion would be admitted without deleting anything
+  forkspacer module delete my-module --dry-run=server`,
+	Args: cobra.ArbitraryArgs,
 	RunE: runDelete,
 }
 
 func init() {
+	deleteCmd.Flags().BoolVarP(&deleteForce, "force", "f", false,
+		"Skip confirmation prompt when deleting several modules")
+	cmd.AddDryRunFlag(deleteCmd)
+	cmd.AddSelectionFlags(deleteCmd)
 	moduleCmd.AddCommand(deleteCmd)
 }
 
 func runDelete(c *cobra.Command, args []string) error {
-	name := args[0]
 	namespace := cmd.GetNamespace()
 	ctx := context.Background()
 
+	selection, err := cmd.GetSelection(c, args)
+	if err != nil {
+		return err
+	}
+
+	dryRun, err := cmd.GetDryRunStrategy(c)
+	if err != nil {
+		return err
+	}
+
 	service, err := module.NewService()
 	if err != nil {
 		return fmt.Errorf("failed to connect to cluster: %w", err)
 	}
 
+	if !selection.IsSingle() {
+		return runDeleteBatch(ctx, service, selection, namespace, dryRun)
+	}
+	name := selection.Names[0]
+
+	if dryRun != cmd.DryRunNone {
+		mod, err := service.Get(ctx, name, namespace)
+		if err != nil {
+			return fmt.Errorf("failed to get module: %w", err)
+		}
+		if dryRun == cmd.DryRunServer {
+			if err := service.Delete(ctx, name, &namespace, client.DryRunAll); err != nil {
+				return fmt.Errorf("failed to delete module: %w", err)
+			}
+		}
+		return cmd.PrintDryRunResult(mod, fmt.Sprintf("module/%s deleted", name), dryRun)
+	}
+
 	fmt.Println()
 	fmt.Printf("%s Deleting module %s in namespace %s...\n",
 		styles.SymbolWarning,
@@ -57,3 +103,56 @@ func runDelete(c *cobra.Command, args []string) error {
 
 	return nil
 }
+
+// runDeleteBatch deletes every selected module concurrently and prints an
+// aggregated result table
+func runDeleteBatch(
+	ctx context.Context,
+	service *module.Service,
+	selection *cmd.Selection,
+	namespace string,
+	dryRun cmd.DryRunStrategy,
+) error {
+	targets, err := resolveModuleTargets(ctx, service, selection, namespace)
+	if err != nil {
+		return err
+	}
+
+	if dryRun == cmd.DryRunNone && !deleteForce {
+		fmt.Println()
+		fmt.Println(styles.WarningStyle.Render(fmt.Sprintf("⚠  About to delete %d module(s) in namespace %s:", len(targets), namespace)))
+		for _, t := range targets {
+			fmt.Printf("  %s %s\n", styles.SymbolBullet, t.Name)
+		}
+		fmt.Println()
+		fmt.Print("Continue? (y/N): ")
+
+		var response string
+		fmt.Scanln(&response)
+		if response != "y" && response != "Y" {
+			fmt.Println()
+			fmt.Println(styles.Info("Deletion cancelled"))
+			fmt.Println()
+			return nil
+		}
+	}
+
+	results := batch.Run(ctx, targets, selection.Concurrency, func(ctx context.Context, t batch.Target) (string, error) {
+		switch dryRun {
+		case cmd.DryRunClient:
+			return "deleted (client dry run)", nil
+		case cmd.DryRunServer:
+			if err := service.Delete(ctx, t.Name, &t.Namespace, client.DryRunAll); err != nil {
+				return "", err
+			}
+			return "deleted (server dry run)", nil
+		}
+
+		if err := service.Delete(ctx, t.Name, &t.Namespace); err != nil {
+			return "", err
+		}
+		return "deleted", nil
+	})
+
+	return cmd.PrintBatchResults("module", results)
+}
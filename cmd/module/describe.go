@@ -0,0 +1,191 @@
+package module
+
+import (
+	"context"
+	"fmt"
+
+	batchv1 "github.com/forkspacer/forkspacer/api/v1"
+	"github.com/spf13/cobra"
+	corev1 "k8s.io/api/core/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+
+	"github.com/forkspacer/cli/cmd"
+	"github.com/forkspacer/cli/pkg/module"
+	"github.com/forkspacer/cli/pkg/printer"
+	"github.com/forkspacer/cli/pkg/styles"
+	workspaceService "github.com/forkspacer/cli/pkg/workspace"
+)
+
+var describeCmd = &cobra.Command{
+	Use:   "describe [name]",
+	Short: "Show a module with its workspace, conditions and events",
+	Long: `Show a module in detail to understand why it is not ready.
+
+In addition to 'module get', describe shows the state of the workspace the
+module belongs to, the status conditions and the Kubernetes events involving
+the module. The first failing component is highlighted at the top.
+
+Examples:
+  # Describe a module
+  forkspacer module describe redis
+
+  # Machine readable output including events
+  forkspacer module describe redis -o yaml`,
+	Args: cobra.ExactArgs(1),
+	RunE: runDescribe,
+}
+
+func init() {
+	moduleCmd.AddCommand(describeCmd)
+}
+
+// moduleDescription is the json/yaml representation of a described module
+type moduleDescription struct {
+	Module    *batchv1.Module    `json:"module"`
+	Workspace *batchv1.Workspace `json:"workspace,omitempty"`
+	Events    []corev1.Event     `json:"events"`
+	Problem   *printer.Problem   `json:"problem,omitempty"`
+}
+
+func runDescribe(c *cobra.Command, args []string) error {
+	name := args[0]
+	namespace := cmd.GetNamespace()
+
+	ctx := context.Background()
+	service, err := module.NewService()
+	if err != nil {
+		return fmt.Errorf("failed to connect to cluster: %w", err)
+	}
+	wsService, err := workspaceService.NewService()
+	if err != nil {
+		return fmt.Errorf("failed to connect to cluster: %w", err)
+	}
+
+	mod, err := service.Get(ctx, name, namespace)
+	if err != nil {
+		return fmt.Errorf("failed to get module: %w", err)
+	}
+
+	workspace, err := wsService.Get(ctx, mod.Spec.Workspace.Name, mod.Spec.Workspace.Namespace)
+	if apierrors.IsNotFound(err) {
+		workspace = nil
+	} else if err != nil {
+		return fmt.Errorf("failed to get workspace: %w", err)
+	}
+
+	events, err := service.Events(ctx, mod)
+	if err != nil {
+		return fmt.Errorf("failed to list events: %w", err)
+	}
+	printer.SortEvents(events)
+
+	description := moduleDescription{
+		Module:    mod,
+		Workspace: workspace,
+		Events:    events,
+		Problem:   moduleProblem(mod, workspace, events),
+	}
+
+	if cmd.IsStructuredOutput() {
+		return printer.PrintObject(description, cmd.GetOutput())
+	}
+
+	fmt.Println()
+	fmt.Println(styles.TitleStyle.Render(fmt.Sprintf("Module: %s", mod.Name)))
+	fmt.Println()
+	printer.PrintProblem(description.Problem)
+	fmt.Println()
+
+	fmt.Println(styles.KeyStyle.Render("Overview"))
+	fmt.Printf("  %s  %s\n", styles.Key("Namespace:"), styles.Value(mod.Namespace))
+	fmt.Printf("  %s  %s\n", styles.Key("Phase:"), styles.Value(string(mod.Status.Phase)))
+	fmt.Printf("  %s  %t\n", styles.Key("Hibernated:"), mod.Spec.Hibernated)
+
+	switch {
+	case mod.Spec.Helm != nil:
+		fmt.Printf("  %s  %s\n", styles.Key("Type:"), styles.Value("helm"))
+		if release, releaseNamespace, ok := module.ReleaseName(mod); ok {
+			fmt.Printf("  %s  %s\n", styles.Key("Release:"), styles.Value(releaseNamespace+"/"+release))
+		}
+	case mod.Spec.Custom != nil:
+		fmt.Printf("  %s  %s\n", styles.Key("Type:"), styles.Value("custom"))
+		fmt.Printf("  %s  %s\n", styles.Key("Image:"), styles.Value(mod.Spec.Custom.Image))
+	}
+
+	if mod.Status.LastActivity != nil {
+		fmt.Printf("  %s  %s\n", styles.Key("Last Activity:"),
+			styles.Value(mod.Status.LastActivity.Format("2006-01-02 15:04:05")))
+	}
+	if mod.Status.Message != nil {
+		fmt.Printf("  %s  %s\n", styles.Key("Message:"), styles.Value(*mod.Status.Message))
+	}
+	fmt.Println()
+
+	fmt.Println(styles.KeyStyle.Render("Workspace"))
+	fmt.Printf("  %s  %s\n", styles.Key("Name:"), styles.Value(mod.Spec.Workspace.Namespace+"/"+mod.Spec.Workspace.Name))
+	if workspace == nil {
+		fmt.Printf("  %s  %s\n", styles.Key("Phase:"), styles.ErrorStyle.Render("not found"))
+	} else {
+		fmt.Printf("  %s  %s\n", styles.Key("Phase:"), styles.Value(string(workspace.Status.Phase)))
+		fmt.Printf("  %s  %t\n", styles.Key("Ready:"), workspace.Status.Ready)
+		fmt.Printf("  %s  %t\n", styles.Key("Hibernated:"), workspace.Spec.Hibernated)
+	}
+	fmt.Println()
+
+	fmt.Println(styles.KeyStyle.Render("Conditions"))
+	printer.PrintConditions(mod.Status.Conditions)
+	fmt.Println()
+
+	fmt.Println(styles.KeyStyle.Render("Events"))
+	printer.PrintEvents(events)
+	fmt.Println()
+
+	return nil
+}
+
+// moduleProblem returns the first failing component of a module: the module
+// itself, an unhealthy condition, its workspace, or the most recent warning
+// event
+func moduleProblem(mod *batchv1.Module, workspace *batchv1.Workspace, events []corev1.Event) *printer.Problem {
+	component := fmt.Sprintf("Module %s/%s", mod.Namespace, mod.Name)
+
+	if mod.Status.Phase == batchv1.ModulePhaseFailed {
+		message := "module failed"
+		if mod.Status.Message != nil {
+			message = *mod.Status.Message
+		}
+		return &printer.Problem{Component: component, Message: message}
+	}
+
+	for _, c := range mod.Status.Conditions {
+		if printer.ConditionUnhealthy(c) {
+			return &printer.Problem{
+				Component: fmt.Sprintf("%s condition %s", component, c.Type),
+				Message:   fmt.Sprintf("%s: %s", c.Reason, c.Message),
+			}
+		}
+	}
+
+	workspaceComponent := fmt.Sprintf("Workspace %s/%s", mod.Spec.Workspace.Namespace, mod.Spec.Workspace.Name)
+	if workspace == nil {
+		return &printer.Problem{Component: workspaceComponent, Message: "referenced workspace does not exist"}
+	}
+	if workspace.Status.Phase == batchv1.WorkspacePhaseFailed {
+		message := "workspace failed"
+		if workspace.Status.Message != nil {
+			message = *workspace.Status.Message
+		}
+		return &printer.Problem{Component: workspaceComponent, Message: message}
+	}
+
+	if mod.Status.Phase == batchv1.ModulePhaseReady {
+		return nil
+	}
+	if event, ok := printer.LatestWarning(events); ok {
+		return &printer.Problem{
+			Component: fmt.Sprintf("%s event %s", component, event.Reason),
+			Message:   event.Message,
+		}
+	}
+	return nil
+}
@@ -0,0 +1,237 @@
+package module
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"os"
+	"strings"
+
+	"github.com/charmbracelet/huh"
+	"github.com/spf13/cobra"
+	"golang.org/x/term"
+	corev1 "k8s.io/api/core/v1"
+	utilexec "k8s.io/client-go/util/exec"
+
+	"github.com/forkspacer/cli/cmd"
+	"github.com/forkspacer/cli/pkg/module"
+	"github.com/forkspacer/cli/pkg/podexec"
+)
+
+// defaultContainerAnnotation names the container kubectl picks by default
+const defaultContainerAnnotation = "kubectl.kubernetes.io/default-container"
+
+var (
+	execPod       string
+	execContainer string
+	execStdin     bool
+	execTTY       bool
+)
+
+var execCmd = &cobra.Command{
+	Use:   "exec [name] -- command [args...]",
+	Short: "Run a command in a pod of a module",
+	Long: `Run a command in a ready pod of a module's Helm release.
+
+Pods are found through the app.kubernetes.io/instance label Helm charts put
+on their workloads. When the release has several ready pods or the pod has
+several containers, you are asked to pick one unless --pod and --container
+are given. Without a terminal the choice is required.
+
+Examples:
+  # Open a shell in the redis module
+  forkspacer module exec redis -it -- sh
+
+  # Run a command in a specific pod and container
+  forkspacer module exec api --pod api-6f9c-x2v4z --container server -- env
+
+  # Pipe a file into a command
+  forkspacer module exec postgres -i -- psql -U app < dump.sql`,
+	Args: func(c *cobra.Command, args []string) error {
+		if c.ArgsLenAtDash() != 1 || len(args) < 2 {
+			return fmt.Errorf("expected a module name and a command after --, e.g. 'forkspacer module exec redis -- sh'")
+		}
+		return nil
+	},
+	RunE: runExec,
+}
+
+func init() {
+	execCmd.Flags().StringVar(&execPod, "pod", "",
+		"Pod to run the command in")
+	execCmd.Flags().StringVarP(&execContainer, "container", "c", "",
+		"Container to run the command in")
+	execCmd.Flags().BoolVarP(&execStdin, "stdin", "i", false,
+		"Pass stdin to the command")
+	execCmd.Flags().BoolVarP(&execTTY, "tty", "t", false,
+		"Allocate a terminal for the command")
+	moduleCmd.AddCommand(execCmd)
+}
+
+func runExec(c *cobra.Command, args []string) error {
+	name := args[0]
+	command := args[1:]
+	namespace := cmd.GetNamespace()
+	ctx := context.Background()
+
+	service, err := module.NewService()
+	if err != nil {
+		return fmt.Errorf("failed to connect to cluster: %w", err)
+	}
+
+	mod, err := service.Get(ctx, name, namespace)
+	if err != nil {
+		return fmt.Errorf("failed to get module: %w", err)
+	}
+	if err := service.CheckLocal(ctx, mod); err != nil {
+		return err
+	}
+
+	if mod.Spec.Hibernated {
+		return fmt.Errorf("module %s is hibernated; wake its workspace first", name)
+	}
+
+	pods, err := service.Pods(ctx, mod)
+	if err != nil {
+		return err
+	}
+
+	pod, err := selectExecPod(pods)
+	if err != nil {
+		return err
+	}
+	container, err := selectExecContainer(pod)
+	if err != nil {
+		return err
+	}
+
+	tty := execTTY
+	if tty && !term.IsTerminal(int(os.Stdin.Fd())) {
+		fmt.Fprintln(os.Stderr, "Unable to use a TTY - input is not a terminal")
+		tty = false
+	}
+
+	err = podexec.Run(ctx, service.RESTConfig(), podexec.Options{
+		Namespace: pod.Namespace,
+		Pod:       pod.Name,
+		Container: container,
+		Command:   command,
+		Stdin:     execStdin,
+		TTY:       tty,
+	})
+
+	// Exit with the status of the remote command, like kubectl exec
+	var exitErr utilexec.ExitError
+	if errors.As(err, &exitErr) && exitErr.Exited() {
+		os.Exit(exitErr.ExitStatus())
+	}
+	return err
+}
+
+// interactive reports whether the user can be asked to pick a pod or container
+func interactive() bool {
+	return term.IsTerminal(int(os.Stdin.Fd())) && term.IsTerminal(int(os.Stdout.Fd()))
+}
+
+// selectExecPod returns the pod named by --pod, the only ready pod, or the
+// ready pod picked by the user
+func selectExecPod(pods []corev1.Pod) (*corev1.Pod, error) {
+	if execPod != "" {
+		for i := range pods {
+			if pods[i].Name == execPod {
+				if !module.PodReady(&pods[i]) {
+					return nil, fmt.Errorf("pod %s is not ready", execPod)
+				}
+				return &pods[i], nil
+			}
+		}
+		return nil, fmt.Errorf("pod %s does not belong to the module", execPod)
+	}
+
+	var ready []*corev1.Pod
+	for i := range pods {
+		if module.PodReady(&pods[i]) {
+			ready = append(ready, &pods[i])
+		}
+	}
+
+	switch {
+	case len(ready) == 0:
+		return nil, fmt.Errorf("the module has no ready pods")
+	case len(ready) == 1:
+		return ready[0], nil
+	case !interactive():
+		names := make([]string, len(ready))
+		for i, pod := range ready {
+			names[i] = pod.Name
+		}
+		return nil, fmt.Errorf("the module has %d ready pods, choose one with --pod: %s", len(ready), strings.Join(names, ", "))
+	}
+
+	options := make([]huh.Option[int], len(ready))
+	for i, pod := range ready {
+		options[i] = huh.NewOption(pod.Name, i)
+	}
+
+	var selected int
+	form := huh.NewForm(
+		huh.NewGroup(
+			huh.NewSelect[int]().
+				Title("Select pod").
+				Options(options...).
+				Value(&selected),
+		),
+	)
+	if err := form.Run(); err != nil {
+		return nil, err
+	}
+	return ready[selected], nil
+}
+
+// selectExecContainer returns the container named by --container, the only
+// container, the pod's default container, or the container picked by the user
+func selectExecContainer(pod *corev1.Pod) (string, error) {
+	names := make([]string, len(pod.Spec.Containers))
+	for i, container := range pod.Spec.Containers {
+		names[i] = container.Name
+	}
+
+	if execContainer != "" {
+		for _, name := range names {
+			if name == execContainer {
+				return name, nil
+			}
+		}
+		return "", fmt.Errorf("pod %s has no container %s (containers: %s)", pod.Name, execContainer, strings.Join(names, ", "))
+	}
+
+	if len(names) == 1 {
+		return names[0], nil
+	}
+	if name := pod.Annotations[defaultContainerAnnotation]; name != "" {
+		return name, nil
+	}
+	if !interactive() {
+		return "", fmt.Errorf("pod %s has %d containers, choose one with --container: %s",
+			pod.Name, len(names), strings.Join(names, ", "))
+	}
+
+	options := make([]huh.Option[string], len(names))
+	for i, name := range names {
+		options[i] = huh.NewOption(name, name)
+	}
+
+	var selected string
+	form := huh.NewForm(
+		huh.NewGroup(
+			huh.NewSelect[string]().
+				Title(fmt.Sprintf("Select container in %s", pod.Name)).
+				Options(options...).
+				Value(&selected),
+		),
+	)
+	if err := form.Run(); err != nil {
+		return "", err
+	}
+	return selected, nil
+}
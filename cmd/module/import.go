@@ -5,10 +5,12 @@ import (
 	"fmt"
 
 	"github.com/charmbracelet/huh"
+	batchv1 "github.com/forkspacer/forkspacer/api/v1"
 	"github.com/spf13/cobra"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/client-go/kubernetes"
 	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client"
 
 	"github.com/forkspacer/cli/cmd"
 	"github.com/forkspacer/cli/pkg/module"
@@ -35,13 +37,17 @@ This command will guide you through:
 
 Examples:
   # Start interactive import
-  forkspacer import`,
+  forkspacer import
+
+  # Walk through the import and print the resulting module instead of creating it
+  forkspacer import --dry-run=client -o yaml`,
 	RunE: runImport,
 }
 
 func init() {
 	// Add to root command directly (forkspacer import)
 	cmd.GetRootCmd().AddCommand(importCmd)
+	cmd.AddDryRunFlag(importCmd)
 }
 
 type importConfig struct {
@@ -68,6 +74,11 @@ type importConfig struct {
 func runImport(c *cobra.Command, args []string) error {
 	ctx := context.Background()
 
+	dryRun, err := cmd.GetDryRunStrategy(c)
+	if err != nil {
+		return err
+	}
+
 	// Initialize Kubernetes client
 	cfg, err := ctrl.GetConfig()
 	if err != nil {
@@ -306,7 +317,7 @@ func runImport(c *cobra.Command, args []string) error {
 	}
 
 	// Step 6: Create the module
-	return createModuleFromConfig(ctx, config)
+	return createModuleFromConfig(ctx, config, dryRun)
 }
 
 func getNamespaces(ctx context.Context, clientset *kubernetes.Clientset) ([]string, error) {
@@ -358,25 +369,29 @@ func getHelmReleases(ctx context.Context, namespace string) ([]string, error) {
 	return releases, nil
 }
 
-func createModuleFromConfig(ctx context.Context, config *importConfig) error {
-	fmt.Println()
-	fmt.Println(styles.TitleStyle.Render(fmt.Sprintf("%s Creating module %s", styles.SymbolSparkles, config.moduleName)))
-	fmt.Println()
+func createModuleFromConfig(ctx context.Context, config *importConfig, dryRun cmd.DryRunStrategy) error {
+	if dryRun == cmd.DryRunClient {
+		return cmd.PrintDryRunResult(buildModuleFromConfig(config),
+			fmt.Sprintf("module/%s created", config.moduleName), dryRun)
+	}
+
+	var opts []client.CreateOption
+	if dryRun == cmd.DryRunServer {
+		opts = append(opts, client.DryRunAll)
+	} else {
+		fmt.Println()
+		fmt.Println(styles.TitleStyle.Render(fmt.Sprintf("%s Creating module %s", styles.SymbolSparkles, config.moduleName)))
+		fmt.Println()
+	}
 
 	service, err := module.NewService()
 	if err != nil {
 		return fmt.Errorf("failed to connect to cluster: %w", err)
 	}
 
-	var moduleResource interface{}
+	var moduleResource *batchv1.Module
 
 	if config.chartSourceType == chartSourceGit {
-		// Set default namespace for auth secret if not provided
-		authSecretNS := config.gitAuthSecretNS
-		if authSecretNS == "" && config.gitAuthSecret != "" {
-			authSecretNS = config.namespace
-		}
-
 		moduleResource, err = service.CreateExistingHelmRelease(
 			ctx,
 			config.moduleName,
@@ -390,15 +405,10 @@ func createModuleFromConfig(ctx context.Context, config *importConfig) error {
 			config.gitPath,
 			config.gitRevision,
 			config.gitAuthSecret,
-			authSecretNS,
+			config.authSecretNamespace(),
+			opts...,
 		)
 	} else {
-		// Set default namespace for auth secret if not provided
-		authSecretNS := config.chartRepoAuthSecretNS
-		if authSecretNS == "" && config.chartRepoAuthSecret != "" {
-			authSecretNS = config.namespace
-		}
-
 		moduleResource, err = service.CreateExistingHelmReleaseWithChartRepo(
 			ctx,
 			config.moduleName,
@@ -412,7 +422,8 @@ func createModuleFromConfig(ctx context.Context, config *importConfig) error {
 			config.publicChartName,
 			config.publicChartVersion,
 			config.chartRepoAuthSecret,
-			authSecretNS,
+			config.authSecretNamespace(),
+			opts...,
 		)
 	}
 
@@ -420,6 +431,10 @@ func createModuleFromConfig(ctx context.Context, config *importConfig) error {
 		return fmt.Errorf("failed to create module: %w", err)
 	}
 
+	if dryRun == cmd.DryRunServer {
+		return cmd.PrintDryRunResult(moduleResource, fmt.Sprintf("module/%s created", config.moduleName), dryRun)
+	}
+
 	// Print success
 	fmt.Println()
 	fmt.Println(styles.SuccessStyle.Render("✓ Module created successfully"))
@@ -429,6 +444,55 @@ func createModuleFromConfig(ctx context.Context, config *importConfig) error {
 	fmt.Printf("  %s %s\n", styles.SymbolArrow, styles.Code(fmt.Sprintf("forkspacer workspace get %s", config.workspace)))
 	fmt.Println()
 
-	_ = moduleResource // Suppress unused variable warning
 	return nil
 }
+
+// buildModuleFromConfig constructs the module described by the import answers
+// without sending it to the cluster
+func buildModuleFromConfig(config *importConfig) *batchv1.Module {
+	if config.chartSourceType == chartSourceGit {
+		return module.BuildExistingHelmRelease(
+			config.moduleName,
+			config.namespace,
+			config.helmRelease,
+			config.helmReleaseNamespace,
+			config.workspace,
+			config.workspaceNamespace,
+			config.hibernated,
+			config.gitRepo,
+			config.gitPath,
+			config.gitRevision,
+			config.gitAuthSecret,
+			config.authSecretNamespace(),
+		)
+	}
+
+	return module.BuildExistingHelmReleaseWithChartRepo(
+		config.moduleName,
+		config.namespace,
+		config.helmRelease,
+		config.helmReleaseNamespace,
+		config.workspace,
+		config.workspaceNamespace,
+		config.hibernated,
+		config.publicChartRepo,
+		config.publicChartName,
+		config.publicChartVersion,
+		config.chartRepoAuthSecret,
+		config.authSecretNamespace(),
+	)
+}
+
+// authSecretNamespace returns the namespace of the selected chart source's
+// auth secret, defaulting to the module namespace when a secret is set
+func (c *importConfig) authSecretNamespace() string {
+	secret, secretNS := c.chartRepoAuthSecret, c.chartRepoAuthSecretNS
+	if c.chartSourceType == chartSourceGit {
+		secret, secretNS = c.gitAuthSecret, c.gitAuthSecretNS
+	}
+
+	if secretNS == "" && secret != "" {
+		return c.namespace
+	}
+	return secretNS
+}
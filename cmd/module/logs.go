@@ -0,0 +1,107 @@
+package module
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"os/signal"
+	"time"
+
+	"github.com/spf13/cobra"
+	"k8s.io/client-go/kubernetes"
+
+	"github.com/forkspacer/cli/cmd"
+	"github.com/forkspacer/cli/pkg/logs"
+	"github.com/forkspacer/cli/pkg/module"
+	"github.com/forkspacer/cli/pkg/styles"
+)
+
+var (
+	logsFollow    bool
+	logsSince     time.Duration
+	logsTail      int64
+	logsContainer string
+)
+
+var logsCmd = &cobra.Command{
+	Use:   "logs [name]",
+	Short: "Stream the logs of a module's pods",
+	Long: `Stream the logs of all containers in the pods of a module's Helm release.
+
+Pods are found through the app.kubernetes.io/instance label Helm charts put
+on their workloads. The logs of all containers are streamed concurrently and
+every line is prefixed with its pod in a distinct color.
+
+Examples:
+  # Print the logs of a module
+  forkspacer module logs redis
+
+  # Follow the last 20 lines of every container
+  forkspacer module logs redis -f --tail 20
+
+  # Logs of the last hour from a single container
+  forkspacer module logs api --since 1h --container server`,
+	Args: cobra.ExactArgs(1),
+	RunE: runLogs,
+}
+
+func init() {
+	logsCmd.Flags().BoolVarP(&logsFollow, "follow", "f", false,
+		"Keep streaming new log lines")
+	logsCmd.Flags().DurationVar(&logsSince, "since", 0,
+		"Only show logs newer than a relative duration like 5m or 2h")
+	logsCmd.Flags().Int64Var(&logsTail, "tail", -1,
+		"Number of recent lines to show per container (-1 for all)")
+	logsCmd.Flags().StringVarP(&logsContainer, "container", "c", "",
+		"Only show logs of this container")
+	moduleCmd.AddCommand(logsCmd)
+}
+
+func runLogs(c *cobra.Command, args []string) error {
+	name := args[0]
+	namespace := cmd.GetNamespace()
+
+	ctx, stop := signal.NotifyContext(context.Background(), os.Interrupt)
+	defer stop()
+
+	service, err := module.NewService()
+	if err != nil {
+		return fmt.Errorf("failed to connect to cluster: %w", err)
+	}
+
+	mod, err := service.Get(ctx, name, namespace)
+	if err != nil {
+		return fmt.Errorf("failed to get module: %w", err)
+	}
+	if err := service.CheckLocal(ctx, mod); err != nil {
+		return err
+	}
+
+	pods, err := service.Pods(ctx, mod)
+	if err != nil {
+		return err
+	}
+	if len(pods) == 0 {
+		if mod.Spec.Hibernated {
+			fmt.Println(styles.Info(fmt.Sprintf("Module %s is hibernated and has no pods", name)))
+			return nil
+		}
+		return fmt.Errorf("no pods found for module %s", name)
+	}
+
+	sources := logs.Sources(pods, logsContainer, "")
+	if len(sources) == 0 {
+		return fmt.Errorf("no container %q in the pods of module %s", logsContainer, name)
+	}
+
+	clientset, err := kubernetes.NewForConfig(service.RESTConfig())
+	if err != nil {
+		return fmt.Errorf("failed to connect to cluster: %w", err)
+	}
+
+	return logs.Stream(ctx, clientset, sources, logs.Options{
+		Follow: logsFollow,
+		Since:  logsSince,
+		Tail:   logsTail,
+	}, os.Stdout)
+}
@@ -1,8 +1,14 @@
 package module
 
 import (
+	"context"
+	"fmt"
+
 	"github.com/forkspacer/cli/cmd"
+	"github.com/forkspacer/cli/pkg/batch"
+	"github.com/forkspacer/cli/pkg/module"
 	"github.com/spf13/cobra"
+	"sigs.k8s.io/controller-runtime/pkg/client"
 )
 
 var moduleCmd = &cobra.Command{
@@ -29,3 +35,40 @@ Examples:
 func init() {
 	cmd.GetRootCmd().AddCommand(moduleCmd)
 }
+
+// resolveModuleTargets expands a selection into the modules it refers to
+func resolveModuleTargets(
+	ctx context.Context,
+	service *module.Service,
+	selection *cmd.Selection,
+	namespace string,
+) ([]batch.Target, error) {
+	var targets []batch.Target
+
+	if len(selection.Names) > 0 {
+		for _, name := range selection.Names {
+			targets = append(targets, batch.Target{Name: name, Namespace: namespace})
+		}
+		return targets, nil
+	}
+
+	var opts []client.ListOption
+	if selection.Selector != nil {
+		opts = append(opts, client.MatchingLabelsSelector{Selector: selection.Selector})
+	}
+
+	modules, err := service.List(ctx, namespace, opts...)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list modules: %w", err)
+	}
+
+	for _, mod := range modules.Items {
+		targets = append(targets, batch.Target{Name: mod.Name, Namespace: mod.Namespace})
+	}
+
+	if len(targets) == 0 {
+		return nil, fmt.Errorf("no modules matched in namespace %s", namespace)
+	}
+
+	return targets, nil
+}
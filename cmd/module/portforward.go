@@ -0,0 +1,141 @@
+package module
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"os/signal"
+
+	"github.com/spf13/cobra"
+
+	"github.com/forkspacer/cli/cmd"
+	"github.com/forkspacer/cli/pkg/module"
+	"github.com/forkspacer/cli/pkg/portforward"
+	"github.com/forkspacer/cli/pkg/styles"
+)
+
+var (
+	portForwardService   string
+	portForwardAddresses []string
+)
+
+var portForwardCmd = &cobra.Command{
+	Use:   "port-forward [name] [local:]remote",
+	Short: "Forward a local port to a module's service",
+	Long: `Forward a local port to a service of a module's Helm release.
+
+The remote port is a port number or name of one of the services labeled with
+the release's app.kubernetes.io/instance label. Connections are forwarded to
+a ready pod behind that service. When the pod goes away, for example because
+the workspace was hibernated and woken up again, the forward reconnects to a
+new ready pod on the same local port.
+
+Examples:
+  # Forward localhost:6379 to port 6379 of the redis module
+  forkspacer module port-forward redis 6379
+
+  # Forward localhost:8080 to the port named http
+  forkspacer module port-forward api 8080:http
+
+  # Pick the service when several expose the port
+  forkspacer module port-forward postgres 5432 --service postgres-primary`,
+	Args: cobra.ExactArgs(2),
+	RunE: runPortForward,
+}
+
+func init() {
+	portForwardCmd.Flags().StringVar(&portForwardService, "service", "",
+		"Service to forward to when several services expose the port")
+	portForwardCmd.Flags().StringSliceVar(&portForwardAddresses, "address", []string{"localhost"},
+		"Local addresses to listen on")
+	moduleCmd.AddCommand(portForwardCmd)
+}
+
+func runPortForward(c *cobra.Command, args []string) error {
+	name := args[0]
+	namespace := cmd.GetNamespace()
+
+	localPort, remotePort, err := portforward.ParsePorts(args[1])
+	if err != nil {
+		return err
+	}
+	if len(portForwardAddresses) == 0 {
+		return fmt.Errorf("--address must not be empty")
+	}
+
+	ctx, stop := signal.NotifyContext(context.Background(), os.Interrupt)
+	defer stop()
+
+	service, err := module.NewService()
+	if err != nil {
+		return fmt.Errorf("failed to connect to cluster: %w", err)
+	}
+
+	resolve := func(ctx context.Context) (*portforward.Target, error) {
+		mod, err := service.Get(ctx, name, namespace)
+		if err != nil {
+			return nil, fmt.Errorf("failed to get module: %w", err)
+		}
+		if err := service.CheckLocal(ctx, mod); err != nil {
+			return nil, err
+		}
+
+		services, err := service.Services(ctx, mod)
+		if err != nil {
+			return nil, err
+		}
+		svc, port, err := module.FindServicePort(services, remotePort, portForwardService)
+		if err != nil {
+			return nil, err
+		}
+
+		pods, err := service.ServicePods(ctx, svc)
+		if err != nil {
+			return nil, fmt.Errorf("failed to list pods of service %s: %w", svc.Name, err)
+		}
+		for i := range pods {
+			if !module.PodReady(&pods[i]) {
+				continue
+			}
+			containerPort, err := module.ContainerPort(&pods[i], port)
+			if err != nil {
+				return nil, err
+			}
+			return &portforward.Target{
+				Namespace: pods[i].Namespace,
+				Pod:       pods[i].Name,
+				Port:      containerPort,
+			}, nil
+		}
+
+		if mod.Spec.Hibernated {
+			return nil, fmt.Errorf("module %s is hibernated; wake its workspace first", name)
+		}
+		return nil, fmt.Errorf("no ready pod behind service %s", svc.Name)
+	}
+
+	var lastRetry string
+	forwarder := &portforward.Forwarder{
+		Config:    service.RESTConfig(),
+		Addresses: portForwardAddresses,
+		LocalPort: localPort,
+		Resolve:   resolve,
+		OnReady: func(localPort int, target *portforward.Target) {
+			lastRetry = ""
+			fmt.Println(styles.Success(fmt.Sprintf("Forwarding %s:%d %s %s:%d",
+				portForwardAddresses[0], localPort, styles.SymbolArrow, target.Pod, target.Port)))
+			fmt.Println(styles.MutedStyle.Render("Press Ctrl+C to stop"))
+		},
+		OnRetry: func(err error) {
+			// Retries repeat every few seconds while a workspace wakes up;
+			// only report when the reason changes
+			if message := err.Error(); message != lastRetry {
+				lastRetry = message
+				fmt.Println(styles.Warning(message + ", reconnecting"))
+			}
+		},
+		ErrOut: os.Stderr,
+	}
+
+	return forwarder.Run(ctx)
+}
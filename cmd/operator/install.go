@@ -0,0 +1,224 @@
+package operator
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"time"
+
+	"github.com/spf13/cobra"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+
+	"github.com/forkspacer/cli/cmd"
+	operatorService "github.com/forkspacer/cli/pkg/operator"
+	"github.com/forkspacer/cli/pkg/printer"
+	"github.com/forkspacer/cli/pkg/styles"
+)
+
+var (
+	applyWait    bool
+	applyTimeout time.Duration
+)
+
+var installCmd = &cobra.Command{
+	Use:   "install",
+	Short: "Install the operator and its CRDs",
+	Long: `Install the Forkspacer operator, its CRDs, RBAC and webhooks into the
+forkspacer-system namespace using server-side apply, then wait for the
+controller deployment to become available.
+
+Use --dry-run=client -o yaml to render the manifests for a GitOps repository
+without contacting the cluster. --dry-run=server validates the manifests
+against the cluster; as nothing is persisted, objects in the forkspacer-system
+namespace can only be validated once the namespace exists and are listed as
+skipped otherwise.
+
+Examples:
+  # Install the operator
+  forkspacer operator install
+
+  # Render the manifests for GitOps
+  forkspacer operator install --dry-run=client -o yaml > forkspacer.yaml
+
+  # Validate the manifests against the cluster without installing
+  forkspacer operator install --dry-run=server`,
+	Args: cobra.NoArgs,
+	RunE: func(c *cobra.Command, args []string) error {
+		return runApply(c, false)
+	},
+}
+
+var upgradeCmd = &cobra.Command{
+	Use:   "upgrade",
+	Short: "Upgrade the operator to the version embedded in the CLI",
+	Long: `Upgrade an installed Forkspacer operator and its CRDs to the manifests
+embedded in the CLI using server-side apply, then wait for the controller
+deployment to become available.
+
+Examples:
+  # Upgrade the operator
+  forkspacer operator upgrade
+
+  # Show the objects that would change
+  forkspacer operator upgrade --dry-run=server -o yaml`,
+	Args: cobra.NoArgs,
+	RunE: func(c *cobra.Command, args []string) error {
+		return runApply(c, true)
+	},
+}
+
+func init() {
+	for _, c := range []*cobra.Command{installCmd, upgradeCmd} {
+		c.Flags().BoolVar(&applyWait, "wait", true,
+			"Wait for the controller deployment to become available")
+		c.Flags().DurationVar(&applyTimeout, "timeout", 5*time.Minute,
+			"How long to wait for the controller deployment")
+		cmd.AddDryRunFlag(c)
+	}
+}
+
+func runApply(c *cobra.Command, upgrade bool) error {
+	dryRun, err := cmd.GetDryRunStrategy(c)
+	if err != nil {
+		return err
+	}
+
+	objects, err := operatorService.Manifests()
+	if err != nil {
+		return err
+	}
+	namespace, name, version, err := operatorService.Deployment(objects)
+	if err != nil {
+		return err
+	}
+
+	if dryRun == cmd.DryRunClient {
+		return printApplyResult(objects, version, dryRun)
+	}
+
+	ctx := context.Background()
+	service, err := operatorService.NewService()
+	if err != nil {
+		return fmt.Errorf("failed to connect to cluster: %w", err)
+	}
+
+	installed, err := service.Installed(ctx)
+	if err != nil {
+		return fmt.Errorf("failed to look up the operator: %w", err)
+	}
+	switch {
+	case upgrade && installed == nil:
+		return fmt.Errorf("the operator is not installed; run 'forkspacer operator install'")
+	case !upgrade && installed != nil:
+		return fmt.Errorf("the operator %s is already installed in %s; run 'forkspacer operator upgrade'",
+			installed.Version, installed.Namespace)
+	}
+
+	certManager, err := service.CertManagerInstalled()
+	if err != nil {
+		return fmt.Errorf("failed to discover cert-manager: %w", err)
+	}
+	if !certManager {
+		return fmt.Errorf("cert-manager is not installed; %s", operatorService.CertManagerHint)
+	}
+
+	if dryRun == cmd.DryRunServer {
+		applied, skipped, err := service.DryRunApply(ctx, objects)
+		if err != nil {
+			return err
+		}
+		if len(skipped) > 0 {
+			status := os.Stdout
+			if cmd.IsStructuredOutput() {
+				status = os.Stderr
+			}
+			fmt.Fprintln(status)
+			fmt.Fprintln(status, styles.Warning(fmt.Sprintf(
+				"%d object(s) were not validated: their namespace or kind is created by the manifests themselves", len(skipped))))
+			for _, obj := range skipped {
+				fmt.Fprintf(status, "  %s %s\n", styles.MutedStyle.Render(styles.SymbolBullet), operatorService.ObjectID(obj))
+			}
+		}
+		return printApplyResult(applied, version, dryRun)
+	}
+
+	action := "Installing"
+	if upgrade {
+		action = fmt.Sprintf("Upgrading from %s to", installed.Version)
+	}
+
+	sp := printer.NewSpinner(fmt.Sprintf("%s operator %s", action, version))
+	if !cmd.IsStructuredOutput() {
+		sp.Start()
+	}
+	applied, err := service.Apply(ctx, objects)
+	sp.Stop()
+	if err != nil {
+		return err
+	}
+
+	if cmd.IsStructuredOutput() {
+		if applyWait {
+			if err := service.WaitForDeployment(ctx, namespace, name, applyTimeout); err != nil {
+				return err
+			}
+		}
+		return printer.PrintManifests(cleanObjects(applied), cmd.GetOutput())
+	}
+
+	fmt.Println(styles.Success(fmt.Sprintf("Applied %d objects", len(applied))))
+	if cmd.IsVerbose() {
+		for _, obj := range applied {
+			fmt.Printf("  %s %s\n", styles.MutedStyle.Render(styles.SymbolBullet), operatorService.ObjectID(obj))
+		}
+	}
+
+	if !applyWait {
+		fmt.Println()
+		return nil
+	}
+
+	sp = printer.NewSpinner(fmt.Sprintf("Waiting for deployment %s/%s", namespace, name))
+	sp.Start()
+	if err := service.WaitForDeployment(ctx, namespace, name, applyTimeout); err != nil {
+		sp.Error("Controller is not available")
+		return err
+	}
+	sp.Success(fmt.Sprintf("Operator %s is available", version))
+	fmt.Println()
+	fmt.Println(styles.MutedStyle.Render("Verify the installation with 'forkspacer check'."))
+	fmt.Println()
+
+	return nil
+}
+
+// printApplyResult prints the objects of a dry run: the manifests for json
+// and yaml output, otherwise a list of the objects that would be applied
+func printApplyResult(objects []*unstructured.Unstructured, version string, dryRun cmd.DryRunStrategy) error {
+	if cmd.IsStructuredOutput() {
+		return printer.PrintManifests(cleanObjects(objects), cmd.GetOutput())
+	}
+
+	fmt.Println()
+	for _, obj := range objects {
+		fmt.Printf("  %s %s\n", styles.MutedStyle.Render(styles.SymbolBullet), operatorService.ObjectID(obj))
+	}
+	return cmd.PrintDryRunResult(nil, fmt.Sprintf("operator %s applied (%d objects)", version, len(objects)), dryRun)
+}
+
+// cleanObjects drops server-populated fields that do not belong in manifests
+func cleanObjects(objects []*unstructured.Unstructured) []*unstructured.Unstructured {
+	cleaned := make([]*unstructured.Unstructured, 0, len(objects))
+	for _, obj := range objects {
+		obj = obj.DeepCopy()
+		obj.SetManagedFields(nil)
+		obj.SetResourceVersion("")
+		obj.SetUID("")
+		obj.SetGeneration(0)
+		obj.SetCreationTimestamp(metav1.Time{})
+		unstructured.RemoveNestedField(obj.Object, "status")
+		cleaned = append(cleaned, obj)
+	}
+	return cleaned
+}
@@ -0,0 +1,27 @@
+package operator
+
+import (
+	"github.com/forkspacer/cli/cmd"
+	"github.com/spf13/cobra"
+)
+
+// OperatorCmd represents the operator command
+var OperatorCmd = &cobra.Command{
+	Use:   "operator",
+	Short: "Install and manage the Forkspacer operator",
+	Long: `Install, upgrade and remove the Forkspacer operator using the manifests
+embedded in the CLI. The embedded manifests match the Forkspacer API version
+the CLI was built against.
+
+The operator's webhooks require cert-manager to be installed in the cluster.`,
+}
+
+func init() {
+	cmd.GetRootCmd().AddCommand(OperatorCmd)
+
+	// Add subcommands
+	OperatorCmd.AddCommand(installCmd)
+	OperatorCmd.AddCommand(upgradeCmd)
+	OperatorCmd.AddCommand(uninstallCmd)
+	OperatorCmd.AddCommand(statusCmd)
+}
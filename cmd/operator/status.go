@@ -0,0 +1,156 @@
+package operator
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/spf13/cobra"
+
+	"github.com/forkspacer/cli/cmd"
+	operatorService "github.com/forkspacer/cli/pkg/operator"
+	"github.com/forkspacer/cli/pkg/printer"
+	"github.com/forkspacer/cli/pkg/styles"
+)
+
+var statusCmd = &cobra.Command{
+	Use:   "status",
+	Short: "Show the installed operator and the objects it consists of",
+	Long: `Show the installed operator version, whether the controller is available
+and which of the embedded manifest objects exist in the cluster.
+
+Examples:
+  # Show the operator status
+  forkspacer operator status
+
+  # Machine readable output
+  forkspacer operator status -o json`,
+	Args: cobra.NoArgs,
+	RunE: runStatus,
+}
+
+// objectStatus tells whether a manifest object exists in the cluster
+type objectStatus struct {
+	Kind      string `json:"kind"`
+	Name      string `json:"name"`
+	Namespace string `json:"namespace,omitempty"`
+	Present   bool   `json:"present"`
+}
+
+// operatorStatus is the json/yaml representation of the operator status
+type operatorStatus struct {
+	Installed       bool           `json:"installed"`
+	Version         string         `json:"version,omitempty"`
+	Image           string         `json:"image,omitempty"`
+	Replicas        int32          `json:"replicas"`
+	ReadyReplicas   int32          `json:"readyReplicas"`
+	EmbeddedVersion string         `json:"embeddedVersion"`
+	UpgradeNeeded   bool           `json:"upgradeNeeded"`
+	CertManager     bool           `json:"certManager"`
+	Objects         []objectStatus `json:"objects"`
+}
+
+func runStatus(c *cobra.Command, args []string) error {
+	objects, err := operatorService.Manifests()
+	if err != nil {
+		return err
+	}
+	_, _, embeddedVersion, err := operatorService.Deployment(objects)
+	if err != nil {
+		return err
+	}
+
+	ctx := context.Background()
+	service, err := operatorService.NewService()
+	if err != nil {
+		return fmt.Errorf("failed to connect to cluster: %w", err)
+	}
+
+	installed, err := service.Installed(ctx)
+	if err != nil {
+		return fmt.Errorf("failed to look up the operator: %w", err)
+	}
+	certManager, err := service.CertManagerInstalled()
+	if err != nil {
+		return fmt.Errorf("failed to discover cert-manager: %w", err)
+	}
+
+	status := operatorStatus{
+		EmbeddedVersion: embeddedVersion,
+		CertManager:     certManager,
+		Objects:         make([]objectStatus, 0, len(objects)),
+	}
+	if installed != nil {
+		status.Installed = true
+		status.Version = installed.Version
+		status.Image = installed.Image
+		status.Replicas = installed.Replicas
+		status.ReadyReplicas = installed.ReadyReplicas
+		status.UpgradeNeeded = installed.Version != embeddedVersion
+	}
+
+	for _, obj := range objects {
+		present, err := service.Exists(ctx, obj)
+		if err != nil {
+			return fmt.Errorf("failed to get %s: %w", operatorService.ObjectID(obj), err)
+		}
+		status.Objects = append(status.Objects, objectStatus{
+			Kind:      obj.GetKind(),
+			Name:      obj.GetName(),
+			Namespace: obj.GetNamespace(),
+			Present:   present,
+		})
+	}
+
+	if cmd.IsStructuredOutput() {
+		return printer.PrintObject(status, cmd.GetOutput())
+	}
+
+	fmt.Println()
+	fmt.Println(styles.TitleStyle.Render("Forkspacer Operator"))
+	fmt.Println()
+
+	if installed == nil {
+		fmt.Printf("  %s  %s\n", styles.Key("Installed:"), styles.ErrorStyle.Render("no"))
+	} else {
+		fmt.Printf("  %s  %s\n", styles.Key("Installed:"), styles.Value(installed.Version))
+		fmt.Printf("  %s  %s\n", styles.Key("Image:"), styles.Value(installed.Image))
+		ready := fmt.Sprintf("%d/%d", installed.ReadyReplicas, installed.Replicas)
+		if installed.ReadyReplicas == 0 {
+			ready = styles.ErrorStyle.Render(ready)
+		}
+		fmt.Printf("  %s  %s\n", styles.Key("Ready:"), ready)
+	}
+	fmt.Printf("  %s  %s\n", styles.Key("Embedded:"), styles.Value(embeddedVersion))
+	if certManager {
+		fmt.Printf("  %s  %s\n", styles.Key("cert-manager:"), styles.Value("installed"))
+	} else {
+		fmt.Printf("  %s  %s\n", styles.Key("cert-manager:"), styles.ErrorStyle.Render("not installed"))
+	}
+	fmt.Println()
+
+	table := printer.NewTable([]string{"KIND", "NAME", "NAMESPACE", "STATUS"})
+	for _, obj := range status.Objects {
+		state := styles.SuccessStyle.Render("present")
+		if !obj.Present {
+			state = styles.ErrorStyle.Render("missing")
+		}
+		table.AddRow([]string{obj.Kind, obj.Name, obj.Namespace, state})
+	}
+	table.Render()
+	fmt.Println()
+
+	switch {
+	case installed == nil:
+		fmt.Println(styles.Info("Install the operator with 'forkspacer operator install'"))
+		fmt.Println()
+	case !certManager:
+		fmt.Println(styles.Warning("cert-manager is required by the operator's webhooks; " + operatorService.CertManagerHint))
+		fmt.Println()
+	case status.UpgradeNeeded:
+		fmt.Println(styles.Info(fmt.Sprintf("Run 'forkspacer operator upgrade' to move from %s to %s",
+			installed.Version, embeddedVersion)))
+		fmt.Println()
+	}
+
+	return nil
+}
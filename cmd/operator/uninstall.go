@@ -0,0 +1,172 @@
+package operator
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/spf13/cobra"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	"github.com/forkspacer/cli/cmd"
+	operatorService "github.com/forkspacer/cli/pkg/operator"
+	"github.com/forkspacer/cli/pkg/printer"
+	"github.com/forkspacer/cli/pkg/styles"
+)
+
+var (
+	uninstallCRDs    bool
+	uninstallForce   bool
+	uninstallTimeout time.Duration
+)
+
+var uninstallCmd = &cobra.Command{
+	Use:   "uninstall",
+	Short: "Remove the operator from the cluster",
+	Long: `Remove the Forkspacer operator, its RBAC, webhooks and namespace.
+
+The CRDs are kept by default so existing workspaces and modules survive a
+reinstall. Pass --crds to delete them as well. Every workspace and module in
+the cluster is then deleted first, while the operator is still running to
+uninstall their Helm releases and remove their finalizers; the operator itself
+is only removed once they are gone.
+
+Examples:
+  # Remove the operator but keep the CRDs
+  forkspacer operator uninstall
+
+  # Remove everything, including all workspaces and modules
+  forkspacer operator uninstall --crds
+
+  # Show what would be deleted
+  forkspacer operator uninstall --crds --dry-run=client`,
+	Args: cobra.NoArgs,
+	RunE: runUninstall,
+}
+
+func init() {
+	uninstallCmd.Flags().BoolVar(&uninstallCRDs, "crds", false,
+		"Also delete the CRDs and with them all workspaces and modules")
+	uninstallCmd.Flags().BoolVarP(&uninstallForce, "force", "f", false,
+		"Skip confirmation prompt")
+	uninstallCmd.Flags().DurationVar(&uninstallTimeout, "timeout", 5*time.Minute,
+		"How long to wait for workspaces and modules to be removed with --crds")
+	cmd.AddDryRunFlag(uninstallCmd)
+}
+
+func runUninstall(c *cobra.Command, args []string) error {
+	dryRun, err := cmd.GetDryRunStrategy(c)
+	if err != nil {
+		return err
+	}
+
+	manifests, err := operatorService.Manifests()
+	if err != nil {
+		return err
+	}
+	var objects []*unstructured.Unstructured
+	for _, obj := range manifests {
+		if obj.GetKind() == "CustomResourceDefinition" && !uninstallCRDs {
+			continue
+		}
+		objects = append(objects, obj)
+	}
+
+	if dryRun == cmd.DryRunClient {
+		return printDeleteResult(objects, dryRun)
+	}
+
+	ctx := context.Background()
+	service, err := operatorService.NewService()
+	if err != nil {
+		return fmt.Errorf("failed to connect to cluster: %w", err)
+	}
+
+	if dryRun == cmd.DryRunServer {
+		if uninstallCRDs {
+			if _, err := service.DeleteCustomResources(ctx, uninstallTimeout, client.DryRunAll); err != nil {
+				return err
+			}
+		}
+		deleted, err := service.Delete(ctx, objects, client.DryRunAll)
+		if err != nil {
+			return err
+		}
+		return printDeleteResult(deleted, dryRun)
+	}
+
+	if !uninstallForce {
+		fmt.Println()
+		fmt.Println(styles.WarningStyle.Render("⚠  About to uninstall the Forkspacer operator"))
+		if uninstallCRDs {
+			resources, err := service.CustomResources(ctx)
+			if err != nil {
+				return err
+			}
+			fmt.Println()
+			fmt.Println(styles.ErrorStyle.Render(fmt.Sprintf(
+				"The CRDs will be deleted together with ALL %d workspace(s) and module(s) in the cluster.", len(resources))))
+		} else {
+			fmt.Println()
+			fmt.Println(styles.MutedStyle.Render("Workspaces and modules are kept but will not be reconciled."))
+		}
+		fmt.Println()
+		fmt.Print("Continue? (y/N): ")
+
+		var response string
+		fmt.Scanln(&response)
+		if response != "y" && response != "Y" {
+			fmt.Println()
+			fmt.Println(styles.Info("Uninstall cancelled"))
+			fmt.Println()
+			return nil
+		}
+	}
+
+	if uninstallCRDs {
+		sp := printer.NewSpinner("Deleting workspaces and modules")
+		sp.Start()
+		count, err := service.DeleteCustomResources(ctx, uninstallTimeout)
+		if err != nil {
+			sp.Error("Failed to delete workspaces and modules")
+			return err
+		}
+		sp.Success(fmt.Sprintf("Deleted %d workspace(s) and module(s)", count))
+	}
+
+	sp := printer.NewSpinner("Uninstalling operator")
+	sp.Start()
+	deleted, err := service.Delete(ctx, objects)
+	if err != nil {
+		sp.Error("Failed to uninstall operator")
+		return err
+	}
+	if len(deleted) == 0 {
+		sp.Success("The operator is not installed")
+		fmt.Println()
+		return nil
+	}
+	sp.Success(fmt.Sprintf("Deleted %d objects", len(deleted)))
+	if cmd.IsVerbose() {
+		for _, obj := range deleted {
+			fmt.Printf("  %s %s\n", styles.MutedStyle.Render(styles.SymbolBullet), operatorService.ObjectID(obj))
+		}
+	}
+	fmt.Println()
+
+	return nil
+}
+
+// printDeleteResult prints the objects a dry run would delete
+func printDeleteResult(objects []*unstructured.Unstructured, dryRun cmd.DryRunStrategy) error {
+	if cmd.IsStructuredOutput() {
+		return printer.PrintManifests(cleanObjects(objects), cmd.GetOutput())
+	}
+
+	fmt.Println()
+	for _, obj := range objects {
+		fmt.Printf("  %s %s\n", styles.MutedStyle.Render(styles.SymbolBullet), operatorService.ObjectID(obj))
+	}
+	return cmd.PrintDryRunResult(nil, fmt.Sprintf("operator uninstalled (%d objects)", len(objects)), dryRun)
+}
@@ -0,0 +1,21 @@
+package report
+
+import (
+	"github.com/forkspacer/cli/cmd"
+	"github.com/spf13/cobra"
+)
+
+// ReportCmd represents the report command
+var ReportCmd = &cobra.Command{
+	Use:   "report",
+	Short: "Generate reports about workspaces",
+	Long: `Generate reports about Forkspacer workspaces, such as the resources saved
+by auto-hibernation.`,
+}
+
+func init() {
+	cmd.GetRootCmd().AddCommand(ReportCmd)
+
+	// Add subcommands
+	ReportCmd.AddCommand(savingsCmd)
+}
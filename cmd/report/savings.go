@@ -0,0 +1,341 @@
+package report
+
+import (
+	"context"
+	"encoding/csv"
+	"fmt"
+	"os"
+	"strconv"
+	"time"
+
+	batchv1 "github.com/forkspacer/forkspacer/api/v1"
+	"github.com/spf13/cobra"
+
+	"github.com/forkspacer/cli/cmd"
+	moduleService "github.com/forkspacer/cli/pkg/module"
+	"github.com/forkspacer/cli/pkg/printer"
+	"github.com/forkspacer/cli/pkg/styles"
+	"github.com/forkspacer/cli/pkg/validation"
+	workspaceService "github.com/forkspacer/cli/pkg/workspace"
+)
+
+var (
+	savingsDays          int
+	savingsAllNamespaces bool
+)
+
+var savingsCmd = &cobra.Command{
+	Use:   "savings",
+	Short: "Estimate the resources saved by auto-hibernation",
+	Long: `Estimate how much auto-hibernation saves for every workspace that has it enabled.
+
+The hibernation and wake schedules are simulated over the coming period and
+combined with the CPU and memory requests of the Deployments, StatefulSets and
+ReplicaSets of the workspace's Helm modules, the workloads the operator scales
+down while hibernated. Manual wakes and hibernations are not taken into account.
+Workspaces without a wake schedule stay hibernated until someone wakes them, so
+their savings are reported as unknown and left out of the total.
+
+Output formats: table (default), csv, json and yaml.
+
+Examples:
+  # Savings over the next 30 days in the current namespace
+  forkspacer report savings
+
+  # Savings over the next quarter across all namespaces as CSV
+  forkspacer report savings -A --days 90 -o csv > savings.csv
+
+  # Machine readable totals
+  forkspacer report savings -o json`,
+	Args: cobra.NoArgs,
+	RunE: runSavings,
+}
+
+func init() {
+	savingsCmd.Flags().IntVar(&savingsDays, "days", 30,
+		"Length of the simulated period in days")
+	savingsCmd.Flags().BoolVarP(&savingsAllNamespaces, "all-namespaces", "A", false,
+		"Report workspaces across all namespaces")
+}
+
+// savingsRow is the estimate for a single workspace
+type savingsRow struct {
+	Name                string  `json:"name"`
+	Namespace           string  `json:"namespace"`
+	HibernationSchedule string  `json:"hibernationSchedule"`
+	WakeSchedule        string  `json:"wakeSchedule,omitempty"`
+	Modules             int     `json:"modules"`
+	Workloads           int     `json:"workloads"`
+	CPUCores            float64 `json:"cpuCores"`
+	MemoryGiB           float64 `json:"memoryGiB"`
+	AwakeHours          float64 `json:"awakeHours"`
+	HibernatedHours     float64 `json:"hibernatedHours"`
+	CPUCoreHoursSaved   float64 `json:"cpuCoreHoursSaved"`
+	MemoryGiBHoursSaved float64 `json:"memoryGiBHoursSaved"`
+	Note                string  `json:"note,omitempty"`
+
+	// SavingsUnknown marks workspaces woken manually, whose hibernated hours
+	// cannot be simulated; their hours and savings are zero
+	SavingsUnknown bool `json:"savingsUnknown,omitempty"`
+}
+
+// savingsReport is the json/yaml representation of the report
+type savingsReport struct {
+	From       time.Time    `json:"from"`
+	To         time.Time    `json:"to"`
+	Workspaces []savingsRow `json:"workspaces"`
+	Total      savingsRow   `json:"total"`
+}
+
+func runSavings(c *cobra.Command, args []string) error {
+	if savingsDays < 1 {
+		return fmt.Errorf("--days must be at least 1")
+	}
+
+	output := cmd.GetOutput()
+	if output != "csv" && output != "table" && output != "wide" && !cmd.IsStructuredOutput() {
+		return fmt.Errorf("unsupported output format %q (expected table, csv, json or yaml)", output)
+	}
+
+	namespace := cmd.GetNamespace()
+	if savingsAllNamespaces {
+		namespace = "" // Empty means all namespaces
+	}
+
+	ctx := context.Background()
+	workspaces, err := workspaceService.NewService()
+	if err != nil {
+		return fmt.Errorf("failed to connect to cluster: %w", err)
+	}
+	modules, err := moduleService.NewService()
+	if err != nil {
+		return fmt.Errorf("failed to connect to cluster: %w", err)
+	}
+
+	list, err := workspaces.List(ctx, namespace)
+	if err != nil {
+		return err
+	}
+
+	from := time.Now().Truncate(time.Minute)
+	to := from.AddDate(0, 0, savingsDays)
+
+	report := savingsReport{
+		From:  from,
+		To:    to,
+		Total: savingsRow{Name: "TOTAL"},
+	}
+
+	unknown := 0
+	for i := range list.Items {
+		row, ok, err := estimateSavings(ctx, modules, &list.Items[i], from, to)
+		if err != nil {
+			return err
+		}
+		if !ok {
+			continue
+		}
+
+		report.Workspaces = append(report.Workspaces, row)
+		report.Total.Modules += row.Modules
+		report.Total.Workloads += row.Workloads
+		report.Total.CPUCores += row.CPUCores
+		report.Total.MemoryGiB += row.MemoryGiB
+		if row.SavingsUnknown {
+			unknown++
+			continue
+		}
+		report.Total.AwakeHours += row.AwakeHours
+		report.Total.HibernatedHours += row.HibernatedHours
+		report.Total.CPUCoreHoursSaved += row.CPUCoreHoursSaved
+		report.Total.MemoryGiBHoursSaved += row.MemoryGiBHoursSaved
+	}
+
+	switch {
+	case cmd.IsStructuredOutput():
+		return printer.PrintObject(report, output)
+	case output == "csv":
+		return printSavingsCSV(report)
+	}
+
+	if len(report.Workspaces) == 0 {
+		fmt.Println()
+		fmt.Println(styles.MutedStyle.Render("No workspaces with auto-hibernation found"))
+		fmt.Println()
+		fmt.Println(styles.SubtitleStyle.Render("Enable it with:"))
+		fmt.Printf("  %s %s\n", styles.SymbolArrow,
+			styles.Code("forkspacer workspace update dev-env --hibernation-schedule \"0 18 * * 1-5\" --wake-schedule \"0 8 * * 1-5\""))
+		fmt.Println()
+		return nil
+	}
+
+	fmt.Println()
+	fmt.Println(styles.TitleStyle.Render(fmt.Sprintf("Hibernation savings, %s to %s",
+		from.Format("2006-01-02"), to.Format("2006-01-02"))))
+	fmt.Println()
+
+	table := printer.NewTable([]string{"NAME", "NAMESPACE", "MODULES", "CPU", "MEMORY", "AWAKE", "HIBERNATED", "CPU SAVED", "MEMORY SAVED", "NOTE"})
+	for _, row := range append(report.Workspaces, report.Total) {
+		awake := fmt.Sprintf("%.0f h", row.AwakeHours)
+		hibernated := fmt.Sprintf("%.0f h (%.0f%%)", row.HibernatedHours, percent(row.HibernatedHours, row.AwakeHours+row.HibernatedHours))
+		cpuSaved := fmt.Sprintf("%.1f core-h", row.CPUCoreHoursSaved)
+		memorySaved := fmt.Sprintf("%.1f GiB-h", row.MemoryGiBHoursSaved)
+		if row.SavingsUnknown {
+			awake, hibernated, cpuSaved, memorySaved = "unknown", "unknown", "unknown", "unknown"
+		}
+
+		table.AddRow([]string{
+			row.Name,
+			row.Namespace,
+			strconv.Itoa(row.Modules),
+			fmt.Sprintf("%.2f cores", row.CPUCores),
+			fmt.Sprintf("%.2f GiB", row.MemoryGiB),
+			awake,
+			hibernated,
+			cpuSaved,
+			memorySaved,
+			row.Note,
+		})
+	}
+	table.Render()
+	fmt.Println()
+	fmt.Println(styles.MutedStyle.Render("Estimates assume no manual wakes or hibernations during the period."))
+	if unknown > 0 {
+		fmt.Println(styles.MutedStyle.Render(fmt.Sprintf(
+			"%d workspace(s) without a wake schedule are left out of the hibernated hours and savings.", unknown)))
+	}
+	fmt.Println()
+
+	return nil
+}
+
+// estimateSavings simulates the auto-hibernation schedule of a workspace. It
+// reports false for workspaces without auto-hibernation.
+func estimateSavings(ctx context.Context, modules *moduleService.Service, workspace *batchv1.Workspace, from, to time.Time) (savingsRow, bool, error) {
+	row := savingsRow{Name: workspace.Name, Namespace: workspace.Namespace}
+
+	autoHibernation := workspace.Spec.AutoHibernation
+	saved, err := workspaceService.SnoozedAutoHibernation(workspace)
+	if err != nil {
+		return row, false, err
+	}
+	if saved != nil {
+		// A snooze is temporary, report the schedule it restores
+		autoHibernation = saved
+		if until, ok := workspaceService.SnoozeUntil(workspace); ok {
+			row.Note = "snoozed until " + until.Local().Format("2006-01-02 15:04")
+		}
+	}
+	if autoHibernation == nil || !autoHibernation.Enabled {
+		return row, false, nil
+	}
+
+	row.HibernationSchedule = autoHibernation.Schedule
+	if autoHibernation.WakeSchedule != nil {
+		row.WakeSchedule = *autoHibernation.WakeSchedule
+	} else {
+		// How long the workspace sleeps depends on when someone wakes it
+		row.SavingsUnknown = true
+		row.Note = joinNote(row.Note, "manual wake, savings unknown")
+	}
+
+	if !row.SavingsUnknown {
+		awake, err := validation.AwakeDuration(row.HibernationSchedule, row.WakeSchedule, from, to)
+		if err != nil {
+			row.Note = joinNote(row.Note, err.Error())
+			return row, true, nil
+		}
+		row.AwakeHours = awake.Hours()
+		row.HibernatedHours = to.Sub(from).Hours() - row.AwakeHours
+	}
+
+	related, err := modules.ListByWorkspace(ctx, workspace.Name, workspace.Namespace)
+	if err != nil {
+		return row, false, fmt.Errorf("failed to list modules of workspace %s/%s: %w", workspace.Namespace, workspace.Name, err)
+	}
+
+	var requests moduleService.ResourceRequests
+	for i := range related {
+		moduleRequests, err := modules.AwakeRequests(ctx, &related[i])
+		if err != nil {
+			return row, false, fmt.Errorf("failed to read workloads of module %s/%s: %w", related[i].Namespace, related[i].Name, err)
+		}
+		requests.Add(moduleRequests)
+	}
+
+	row.Modules = len(related)
+	row.Workloads = requests.Workloads
+	row.CPUCores = requests.CPUCores
+	row.MemoryGiB = requests.MemoryGiB
+	row.CPUCoreHoursSaved = requests.CPUCores * row.HibernatedHours
+	row.MemoryGiBHoursSaved = requests.MemoryGiB * row.HibernatedHours
+
+	if row.Workloads == 0 {
+		row.Note = joinNote(row.Note, "no workloads found")
+	}
+
+	return row, true, nil
+}
+
+func printSavingsCSV(report savingsReport) error {
+	w := csv.NewWriter(os.Stdout)
+	if err := w.Write([]string{
+		"name", "namespace", "hibernation_schedule", "wake_schedule", "modules", "workloads",
+		"cpu_cores", "memory_gib", "awake_hours", "hibernated_hours",
+		"cpu_core_hours_saved", "memory_gib_hours_saved", "note",
+	}); err != nil {
+		return err
+	}
+
+	for _, row := range append(report.Workspaces, report.Total) {
+		// Unknown savings are left empty rather than written as zero
+		hours := []string{
+			formatFloat(row.AwakeHours),
+			formatFloat(row.HibernatedHours),
+			formatFloat(row.CPUCoreHoursSaved),
+			formatFloat(row.MemoryGiBHoursSaved),
+		}
+		if row.SavingsUnknown {
+			hours = []string{"", "", "", ""}
+		}
+
+		if err := w.Write([]string{
+			row.Name,
+			row.Namespace,
+			row.HibernationSchedule,
+			row.WakeSchedule,
+			strconv.Itoa(row.Modules),
+			strconv.Itoa(row.Workloads),
+			formatFloat(row.CPUCores),
+			formatFloat(row.MemoryGiB),
+			hours[0],
+			hours[1],
+			hours[2],
+			hours[3],
+			row.Note,
+		}); err != nil {
+			return err
+		}
+	}
+
+	w.Flush()
+	return w.Error()
+}
+
+func formatFloat(f float64) string {
+	return strconv.FormatFloat(f, 'f', 2, 64)
+}
+
+func percent(part, total float64) float64 {
+	if total == 0 {
+		return 0
+	}
+	return part / total * 100
+}
+
+func joinNote(note, addition string) string {
+	if note == "" {
+		return addition
+	}
+	return note + "; " + addition
+}
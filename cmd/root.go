@@ -42,7 +42,7 @@ func init() {
 	rootCmd.PersistentFlags().StringVarP(&namespace, "namespace", "n", "default",
 		"Kubernetes namespace")
 	rootCmd.PersistentFlags().StringVarP(&output, "output", "o", "table",
-		"Output format (table|json|yaml)")
+		"Output format (table|wide|json|yaml; dot|mermaid for workspace tree, env|dotenv for workspace endpoints, csv for report savings)")
 	rootCmd.PersistentFlags().BoolVarP(&verbose, "verbose", "v", false,
 		"Enable verbose output")
 
@@ -82,6 +82,12 @@ func GetOutput() string {
 	return output
 }
 
+// IsStructuredOutput reports whether the output format is machine readable (json|yaml)
+// rather than one of the human readable table formats
+func IsStructuredOutput() bool {
+	return output == "json" || output == "yaml"
+}
+
 // IsVerbose returns whether verbose mode is enabled
 func IsVerbose() bool {
 	return verbose
@@ -0,0 +1,87 @@
+package schedule
+
+import (
+	"fmt"
+	"time"
+
+	"github.com/spf13/cobra"
+
+	"github.com/forkspacer/cli/cmd"
+	"github.com/forkspacer/cli/pkg/printer"
+	"github.com/forkspacer/cli/pkg/styles"
+	"github.com/forkspacer/cli/pkg/validation"
+)
+
+var buildCmd = &cobra.Command{
+	Use:   "build",
+	Short: "Build a hibernation schedule interactively",
+	Long: `Build a validated hibernation and wake schedule pair by answering a few
+questions: working days, wake time, sleep time and timezone.
+
+The resulting schedules can be passed to 'workspace create' or
+'workspace update'. 'workspace create --interactive' runs the same builder.
+
+Examples:
+  # Build a schedule and print the flags to use it
+  forkspacer schedule build
+
+  # Print the schedule pair as JSON
+  forkspacer schedule build -o json`,
+	Args: cobra.NoArgs,
+	RunE: runBuild,
+}
+
+func runBuild(c *cobra.Command, args []string) error {
+	pair, err := cmd.RunScheduleBuilder()
+	if err != nil {
+		return err
+	}
+
+	if cmd.IsStructuredOutput() {
+		return printer.PrintObject(pair, cmd.GetOutput())
+	}
+
+	PrintSchedulePair(pair)
+
+	fmt.Println(styles.SubtitleStyle.Render("Use it with:"))
+	flags := fmt.Sprintf("--hibernation-schedule %q --wake-schedule %q", pair.Sleep, pair.Wake)
+	fmt.Printf("  %s %s\n", styles.SymbolArrow, styles.Code("forkspacer workspace create <name> "+flags))
+	fmt.Printf("  %s %s\n", styles.SymbolArrow, styles.Code("forkspacer workspace update <name> "+flags))
+	fmt.Println()
+
+	return nil
+}
+
+// PrintSchedulePair prints a schedule pair with descriptions and the next
+// transitions it produces
+func PrintSchedulePair(pair validation.SchedulePair) {
+	fmt.Println()
+	for _, entry := range []struct{ label, schedule string }{
+		{"Hibernation Schedule:", pair.Sleep},
+		{"Wake Schedule:", pair.Wake},
+	} {
+		description, _ := validation.DescribeSchedule(entry.schedule)
+		fmt.Printf("%s  %s\n", styles.Key(entry.label), styles.Code(entry.schedule))
+		fmt.Printf("  %s\n", styles.MutedStyle.Render(description))
+	}
+
+	if analysis, err := validation.AnalyzeSchedulePair(pair.Sleep, pair.Wake, time.Now()); err == nil {
+		fmt.Printf("%s  %s\n", styles.Key("Awake per Week:"),
+			styles.Value(fmt.Sprintf("%.0f hours (%.0f%%)", analysis.AwakeHours, analysis.AwakeHours/168*100)))
+	}
+
+	transitions, err := validation.UpcomingTransitions(pair.Sleep, pair.Wake, time.Now(), 4)
+	if err == nil && len(transitions) > 0 {
+		zone := validation.ScheduleTimezone(pair.Sleep)
+		fmt.Println()
+		fmt.Println(styles.SubtitleStyle.Render("Upcoming (local time):"))
+		for _, t := range transitions {
+			label := "Sleep"
+			if t.Kind == validation.TransitionWake {
+				label = "Wake "
+			}
+			fmt.Printf("  %s %s  %s\n", styles.SymbolBullet, label, printer.FormatScheduleInstant(t.Time, zone))
+		}
+	}
+	fmt.Println()
+}
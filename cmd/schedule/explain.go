@@ -0,0 +1,118 @@
+package schedule
+
+import (
+	"fmt"
+	"time"
+
+	"github.com/spf13/cobra"
+
+	"github.com/forkspacer/cli/cmd"
+	"github.com/forkspacer/cli/pkg/printer"
+	"github.com/forkspacer/cli/pkg/styles"
+	"github.com/forkspacer/cli/pkg/validation"
+)
+
+var (
+	explainCount    int
+	explainTimezone string
+)
+
+var explainCmd = &cobra.Command{
+	Use:   "explain [cron]",
+	Short: "Describe a cron schedule in plain English",
+	Long: `Describe a cron schedule in plain English and list when it fires next.
+
+Times are shown in your local timezone and in UTC. Schedules without a
+CRON_TZ= prefix are evaluated in UTC, like the operator does.
+
+Examples:
+  # Explain a weekday hibernation schedule
+  forkspacer schedule explain "0 18 * * 1-5"
+
+  # Show the next 10 occurrences of a schedule with a seconds field
+  forkspacer schedule explain "30 0 8 * * *" --count 10
+
+  # Evaluate the schedule in Berlin time
+  forkspacer schedule explain "0 8 * * 1-5" --timezone Europe/Berlin
+
+  # Machine readable output
+  forkspacer schedule explain @daily -o json`,
+	Args: cobra.ExactArgs(1),
+	RunE: runExplain,
+}
+
+func init() {
+	explainCmd.Flags().IntVar(&explainCount, "count", 5,
+		"Number of upcoming occurrences to show")
+	explainCmd.Flags().StringVar(&explainTimezone, "timezone", "",
+		"IANA timezone to evaluate the schedule in (e.g., Europe/Berlin)")
+}
+
+// explanation is the json/yaml representation of an explained schedule
+type explanation struct {
+	Schedule    string      `json:"schedule"`
+	Description string      `json:"description"`
+	Timezone    string      `json:"timezone"`
+	Next        []time.Time `json:"next"`
+}
+
+func runExplain(c *cobra.Command, args []string) error {
+	schedule := args[0]
+
+	if explainCount < 0 {
+		return fmt.Errorf("--count must not be negative")
+	}
+
+	if explainTimezone != "" {
+		var err error
+		schedule, err = validation.WithTimezone(schedule, explainTimezone)
+		if err != nil {
+			return fmt.Errorf("invalid --timezone: %w", err)
+		}
+	}
+
+	if err := validation.ValidateCronSchedule(schedule); err != nil {
+		return err
+	}
+
+	description, err := validation.DescribeSchedule(schedule)
+	if err != nil {
+		return err
+	}
+
+	next, err := validation.NextOccurrences(schedule, time.Now(), explainCount)
+	if err != nil {
+		return err
+	}
+
+	zone := validation.ScheduleTimezone(schedule)
+
+	if cmd.IsStructuredOutput() {
+		return printer.PrintObject(explanation{
+			Schedule:    schedule,
+			Description: description,
+			Timezone:    zone,
+			Next:        next,
+		}, cmd.GetOutput())
+	}
+
+	fmt.Println()
+	fmt.Printf("%s  %s\n", styles.Key("Schedule:"), styles.Code(schedule))
+	fmt.Printf("%s  %s\n", styles.Key("Meaning:"), styles.Value(description))
+	if prefixed, _ := validation.SplitScheduleTimezone(schedule); prefixed == "" {
+		fmt.Printf("%s  %s\n", styles.Key("Timezone:"), styles.Value(zone+" (operator default)"))
+	} else {
+		fmt.Printf("%s  %s\n", styles.Key("Timezone:"), styles.Value(zone))
+	}
+
+	if len(next) > 0 {
+		fmt.Println()
+		fmt.Println(styles.SubtitleStyle.Render("Next occurrences (local time):"))
+		for _, t := range next {
+			fmt.Printf("  %s %s\n", styles.SymbolBullet, printer.FormatScheduleInstant(t, zone))
+		}
+	}
+	fmt.Println()
+
+	return nil
+}
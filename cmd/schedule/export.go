@@ -0,0 +1,260 @@
+package schedule
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+	"time"
+
+	batchv1 "github.com/forkspacer/forkspacer/api/v1"
+	"github.com/spf13/cobra"
+
+	"github.com/forkspacer/cli/cmd"
+	"github.com/forkspacer/cli/pkg/calendar"
+	"github.com/forkspacer/cli/pkg/styles"
+	"github.com/forkspacer/cli/pkg/validation"
+	workspaceService "github.com/forkspacer/cli/pkg/workspace"
+)
+
+var (
+	exportICal          bool
+	exportFile          string
+	exportDir           string
+	exportDays          int
+	exportAllNamespaces bool
+)
+
+var exportCmd = &cobra.Command{
+	Use:   "export [workspace...]",
+	Short: "Export hibernation schedules as calendars",
+	Long: `Export the auto-hibernation schedules of workspaces as iCalendar (.ics) files,
+so the times environments are hibernated show up in a calendar.
+
+Each hibernated period becomes an event from the hibernation to the next
+scheduled wake. Schedules that fire at a fixed time on fixed weekdays are
+exported as weekly recurring events; others, such as steps or days of the
+month, are expanded into single events for the next --days days. Workspaces
+without a wake schedule get a zero-length marker event for each hibernation.
+
+Without workspace names every workspace with auto-hibernation enabled in
+the namespace is exported. By default one combined calendar is written to
+stdout; use --file to write it to a file or --dir to write one file per
+workspace.
+
+Examples:
+  # Combined calendar of the current namespace
+  forkspacer schedule export --ical > hibernation.ics
+
+  # One calendar for a single workspace
+  forkspacer schedule export dev-env --ical --file dev-env.ics
+
+  # One file per workspace across all namespaces
+  forkspacer schedule export --ical -A --dir calendars/`,
+	RunE: runExport,
+}
+
+func init() {
+	exportCmd.Flags().BoolVar(&exportICal, "ical", false,
+		"Export as iCalendar (currently the only supported format)")
+	exportCmd.Flags().StringVar(&exportFile, "file", "",
+		"Write one combined calendar to this file instead of stdout")
+	exportCmd.Flags().StringVar(&exportDir, "dir", "",
+		"Write one <namespace>-<name>.ics file per workspace to this directory")
+	exportCmd.Flags().IntVar(&exportDays, "days", 90,
+		"Days of single events to generate for schedules that cannot recur weekly")
+	exportCmd.Flags().BoolVarP(&exportAllNamespaces, "all-namespaces", "A", false,
+		"Export workspaces across all namespaces")
+
+	exportCmd.MarkFlagsMutuallyExclusive("file", "dir")
+}
+
+// exportedCalendar is the calendar of a single workspace
+type exportedCalendar struct {
+	workspace *batchv1.Workspace
+	calendar  calendar.Calendar
+}
+
+func runExport(c *cobra.Command, args []string) error {
+	if !exportICal {
+		return fmt.Errorf("an export format is required, use --ical")
+	}
+	if exportDays < 1 {
+		return fmt.Errorf("--days must be at least 1")
+	}
+
+	namespace := cmd.GetNamespace()
+	if exportAllNamespaces {
+		if len(args) > 0 {
+			return fmt.Errorf("workspace names cannot be combined with --all-namespaces")
+		}
+		namespace = "" // Empty means all namespaces
+	}
+
+	ctx := context.Background()
+	service, err := workspaceService.NewService()
+	if err != nil {
+		return fmt.Errorf("failed to connect to cluster: %w", err)
+	}
+
+	var workspaces []batchv1.Workspace
+	if len(args) > 0 {
+		for _, name := range args {
+			workspace, err := service.Get(ctx, name, namespace)
+			if err != nil {
+				return err
+			}
+			workspaces = append(workspaces, *workspace)
+		}
+	} else {
+		list, err := service.List(ctx, namespace)
+		if err != nil {
+			return err
+		}
+		workspaces = list.Items
+	}
+
+	from := time.Now().Truncate(time.Minute)
+	to := from.AddDate(0, 0, exportDays)
+
+	var calendars []exportedCalendar
+	for i := range workspaces {
+		workspace := &workspaces[i]
+		events, ok, err := workspaceEvents(workspace, from, to)
+		if err != nil {
+			return fmt.Errorf("failed to export workspace %s/%s: %w", workspace.Namespace, workspace.Name, err)
+		}
+		if !ok {
+			if len(args) > 0 {
+				return fmt.Errorf("workspace %s/%s has no auto-hibernation schedule", workspace.Namespace, workspace.Name)
+			}
+			continue
+		}
+
+		calendars = append(calendars, exportedCalendar{
+			workspace: workspace,
+			calendar: calendar.Calendar{
+				Name:   fmt.Sprintf("%s hibernation", workspace.Name),
+				Events: events,
+			},
+		})
+	}
+
+	if len(calendars) == 0 {
+		return fmt.Errorf("no workspaces with auto-hibernation found")
+	}
+
+	if exportDir != "" {
+		return writeCalendarFiles(calendars)
+	}
+
+	combined := calendar.Calendar{Name: "Forkspacer hibernation"}
+	for _, exported := range calendars {
+		combined.Events = append(combined.Events, exported.calendar.Events...)
+	}
+
+	if exportFile == "" {
+		return combined.Write(os.Stdout)
+	}
+	if err := writeCalendarFile(exportFile, &combined); err != nil {
+		return err
+	}
+
+	fmt.Println(styles.Success(fmt.Sprintf("Wrote %d workspace schedule(s) to %s", len(calendars), exportFile)))
+	return nil
+}
+
+// workspaceEvents converts the auto-hibernation of a workspace into events.
+// It reports false for workspaces without auto-hibernation. A snoozed
+// workspace exports the schedule its snooze restores.
+func workspaceEvents(workspace *batchv1.Workspace, from, to time.Time) ([]calendar.Event, bool, error) {
+	autoHibernation := workspace.Spec.AutoHibernation
+	saved, err := workspaceService.SnoozedAutoHibernation(workspace)
+	if err != nil {
+		return nil, false, err
+	}
+	if saved != nil {
+		autoHibernation = saved
+	}
+	if autoHibernation == nil || !autoHibernation.Enabled {
+		return nil, false, nil
+	}
+
+	sleep := autoHibernation.Schedule
+	wake := ""
+	if autoHibernation.WakeSchedule != nil {
+		wake = *autoHibernation.WakeSchedule
+	}
+
+	uid := fmt.Sprintf("%s.%s", workspace.Name, workspace.Namespace)
+	events, err := calendar.ScheduleEvents(uid, sleep, wake, from, to)
+	if err != nil {
+		return nil, false, err
+	}
+
+	summary := fmt.Sprintf("%s hibernated", workspace.Name)
+	if wake == "" {
+		summary = fmt.Sprintf("%s hibernates", workspace.Name)
+	}
+
+	description := []string{
+		fmt.Sprintf("Workspace %s/%s is hibernated by its auto-hibernation schedule.", workspace.Namespace, workspace.Name),
+		"Hibernation: " + sleep + " (" + explainSchedule(sleep) + ")",
+	}
+	if wake != "" {
+		description = append(description, "Wake: "+wake+" ("+explainSchedule(wake)+")")
+	} else {
+		description = append(description, "Wake: manual")
+	}
+
+	for i := range events {
+		events[i].UID += "@forkspacer"
+		events[i].Summary = summary
+		events[i].Description = strings.Join(description, "\n")
+	}
+	return events, true, nil
+}
+
+// explainSchedule describes a schedule, falling back to a generic phrase
+func explainSchedule(schedule string) string {
+	description, err := validation.DescribeSchedule(schedule)
+	if err != nil {
+		return "custom schedule"
+	}
+	return description
+}
+
+func writeCalendarFiles(calendars []exportedCalendar) error {
+	if err := os.MkdirAll(exportDir, 0o755); err != nil {
+		return fmt.Errorf("failed to create directory %s: %w", exportDir, err)
+	}
+
+	for i := range calendars {
+		workspace := calendars[i].workspace
+		path := filepath.Join(exportDir, fmt.Sprintf("%s-%s.ics", workspace.Namespace, workspace.Name))
+		if err := writeCalendarFile(path, &calendars[i].calendar); err != nil {
+			return err
+		}
+		fmt.Printf("  %s %s\n", styles.SymbolArrow, path)
+	}
+
+	fmt.Println(styles.Success(fmt.Sprintf("Wrote %d calendar(s) to %s", len(calendars), exportDir)))
+	return nil
+}
+
+func writeCalendarFile(path string, cal *calendar.Calendar) error {
+	f, err := os.Create(path)
+	if err != nil {
+		return fmt.Errorf("failed to create %s: %w", path, err)
+	}
+
+	err = cal.Write(f)
+	if closeErr := f.Close(); err == nil {
+		err = closeErr
+	}
+	if err != nil {
+		return fmt.Errorf("failed to write %s: %w", path, err)
+	}
+	return nil
+}
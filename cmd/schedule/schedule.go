@@ -0,0 +1,26 @@
+package schedule
+
+import (
+	"github.com/forkspacer/cli/cmd"
+	"github.com/spf13/cobra"
+)
+
+// ScheduleCmd represents the schedule command
+var ScheduleCmd = &cobra.Command{
+	Use:   "schedule",
+	Short: "Work with auto-hibernation schedules",
+	Long: `Inspect and build the cron schedules used for workspace auto-hibernation.
+
+Schedules use the operator's cron format: 5 fields, or 6 with a leading
+seconds field, @descriptors such as @daily, and an optional CRON_TZ= prefix
+naming the timezone they are evaluated in.`,
+}
+
+func init() {
+	cmd.GetRootCmd().AddCommand(ScheduleCmd)
+
+	// Add subcommands
+	ScheduleCmd.AddCommand(explainCmd)
+	ScheduleCmd.AddCommand(buildCmd)
+	ScheduleCmd.AddCommand(exportCmd)
+}
@@ -0,0 +1,106 @@
+package cmd
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+	"time"
+
+	"github.com/charmbracelet/huh"
+
+	"github.com/forkspacer/cli/pkg/styles"
+	"github.com/forkspacer/cli/pkg/validation"
+)
+
+// RunScheduleBuilder asks for working days, wake and sleep time and timezone
+// and returns the resulting validated schedule pair. With json/yaml output the
+// form is drawn on stderr so stdout stays clean.
+func RunScheduleBuilder() (validation.SchedulePair, error) {
+	days := []time.Weekday{time.Monday, time.Tuesday, time.Wednesday, time.Thursday, time.Friday}
+	wakeTime := "08:00"
+	sleepTime := "18:00"
+	zone := localTimezone()
+
+	dayOptions := make([]huh.Option[time.Weekday], 7)
+	for i := range dayOptions {
+		// Start the week on Monday
+		day := time.Weekday((i + 1) % 7)
+		dayOptions[i] = huh.NewOption(day.String(), day)
+	}
+
+	clockValidator := func(s string) error {
+		_, _, err := validation.ParseClock(s)
+		return err
+	}
+
+	form := huh.NewForm(
+		huh.NewGroup(
+			huh.NewMultiSelect[time.Weekday]().
+				Title("Working days").
+				Description("The workspace is woken on these days").
+				Options(dayOptions...).
+				Value(&days).
+				Validate(func(selected []time.Weekday) error {
+					if len(selected) == 0 {
+						return fmt.Errorf("select at least one working day")
+					}
+					return nil
+				}),
+			huh.NewInput().
+				Title("Wake time (HH:MM)").
+				Placeholder("08:00").
+				Value(&wakeTime).
+				Validate(clockValidator),
+			huh.NewInput().
+				Title("Sleep time (HH:MM)").
+				Description("An earlier time than the wake time means the next morning").
+				Placeholder("18:00").
+				Value(&sleepTime).
+				Validate(clockValidator),
+			huh.NewInput().
+				Title("Timezone").
+				Description("IANA zone name, e.g. Europe/Berlin or America/New_York").
+				Placeholder("UTC").
+				Value(&zone).
+				Validate(func(s string) error {
+					_, err := validation.ValidateTimezone(s)
+					return err
+				}),
+		),
+	)
+
+	if IsStructuredOutput() {
+		form = form.WithOutput(os.Stderr)
+	} else {
+		fmt.Println()
+		fmt.Println(styles.TitleStyle.Render("Build Hibernation Schedule"))
+		fmt.Println()
+	}
+
+	if err := form.Run(); err != nil {
+		return validation.SchedulePair{}, err
+	}
+
+	return validation.BuildSchedulePair(days, wakeTime, sleepTime, zone)
+}
+
+// localTimezone guesses the IANA name of the local zone from $TZ or the
+// /etc/localtime symlink, falling back to UTC
+func localTimezone() string {
+	if zone := os.Getenv("TZ"); zone != "" {
+		if _, err := validation.ValidateTimezone(zone); err == nil {
+			return zone
+		}
+	}
+
+	if target, err := filepath.EvalSymlinks("/etc/localtime"); err == nil {
+		if _, zone, ok := strings.Cut(target, "zoneinfo/"); ok {
+			if _, err := validation.ValidateTimezone(zone); err == nil {
+				return zone
+			}
+		}
+	}
+
+	return "UTC"
+}
@@ -1,11 +1,16 @@
 package cmd
 
 import (
+	"context"
 	"fmt"
 	"runtime"
+	"strings"
 
+	"github.com/forkspacer/cli/pkg/preflight"
+	"github.com/forkspacer/cli/pkg/printer"
 	"github.com/forkspacer/cli/pkg/styles"
 	"github.com/spf13/cobra"
+	ctrl "sigs.k8s.io/controller-runtime"
 )
 
 var (
@@ -15,22 +20,155 @@ var (
 	buildDate = "unknown"
 )
 
+var versionClientOnly bool
+
 var versionCmd = &cobra.Command{
 	Use:   "version",
 	Short: "Print version information",
-	Run:   runVersion,
+	Long: `Print the version of the CLI and of the cluster it talks to.
+
+The server section shows the Kubernetes version, the detected operator
+version, the served Forkspacer API versions and whether they are compatible
+with the API this CLI was built against. Use --client to skip the cluster.
+
+Examples:
+  # Client and server versions
+  forkspacer version
+
+  # Client version only, without contacting the cluster
+  forkspacer version --client
+
+  # Machine readable output
+  forkspacer version -o json`,
+	Args: cobra.NoArgs,
+	RunE: runVersion,
 }
 
 func init() {
+	versionCmd.Flags().BoolVar(&versionClientOnly, "client", false,
+		"Only print the client version, without contacting the cluster")
 	rootCmd.AddCommand(versionCmd)
 }
 
-func runVersion(cmd *cobra.Command, args []string) {
+// clientVersion describes the CLI binary
+type clientVersion struct {
+	Version    string `json:"version"`
+	GitCommit  string `json:"gitCommit"`
+	BuildDate  string `json:"buildDate"`
+	GoVersion  string `json:"goVersion"`
+	Platform   string `json:"platform"`
+	APIVersion string `json:"apiVersion"`
+}
+
+// serverVersion describes the cluster and the installed operator
+type serverVersion struct {
+	KubernetesVersion string            `json:"kubernetesVersion"`
+	OperatorVersion   string            `json:"operatorVersion,omitempty"`
+	OperatorImage     string            `json:"operatorImage,omitempty"`
+	CRDVersions       []string          `json:"crdVersions"`
+	Compatibility     string            `json:"compatibility"`
+	Issues            []preflight.Issue `json:"issues,omitempty"`
+}
+
+// versionInfo is the json/yaml representation of the version command
+type versionInfo struct {
+	Client clientVersion  `json:"client"`
+	Server *serverVersion `json:"server,omitempty"`
+}
+
+func runVersion(cmd *cobra.Command, args []string) error {
+	info := versionInfo{
+		Client: clientVersion{
+			Version:    version,
+			GitCommit:  gitCommit,
+			BuildDate:  buildDate,
+			GoVersion:  runtime.Version(),
+			Platform:   runtime.GOOS + "/" + runtime.GOARCH,
+			APIVersion: preflight.APIVersion(),
+		},
+	}
+
+	var serverErr error
+	if !versionClientOnly {
+		info.Server, serverErr = getServerVersion()
+	}
+
+	if IsStructuredOutput() {
+		if err := printer.PrintObject(info, GetOutput()); err != nil {
+			return err
+		}
+		return serverErr
+	}
+
 	fmt.Println(styles.TitleStyle.Render("Forkspacer CLI"))
 	fmt.Println()
-	fmt.Printf("%s  %s\n", styles.Key("Version:"), styles.Value(version))
-	fmt.Printf("%s  %s\n", styles.Key("Git Commit:"), styles.Value(gitCommit))
-	fmt.Printf("%s  %s\n", styles.Key("Build Date:"), styles.Value(buildDate))
-	fmt.Printf("%s  %s\n", styles.Key("Go Version:"), styles.Value(runtime.Version()))
-	fmt.Printf("%s  %s/%s\n", styles.Key("Platform:"), styles.Value(runtime.GOOS), styles.Value(runtime.GOARCH))
+	fmt.Printf("%s  %s\n", styles.Key("Version:"), styles.Value(info.Client.Version))
+	fmt.Printf("%s  %s\n", styles.Key("Git Commit:"), styles.Value(info.Client.GitCommit))
+	fmt.Printf("%s  %s\n", styles.Key("Build Date:"), styles.Value(info.Client.BuildDate))
+	fmt.Printf("%s  %s\n", styles.Key("Go Version:"), styles.Value(info.Client.GoVersion))
+	fmt.Printf("%s  %s\n", styles.Key("Platform:"), styles.Value(info.Client.Platform))
+	fmt.Printf("%s  %s\n", styles.Key("API Version:"), styles.Value(info.Client.APIVersion))
+
+	if info.Server == nil {
+		return serverErr
+	}
+
+	server := info.Server
+	fmt.Println()
+	fmt.Println(styles.TitleStyle.Render("Server"))
+	fmt.Println()
+	fmt.Printf("%s  %s\n", styles.Key("Kubernetes:"), styles.Value(server.KubernetesVersion))
+	if server.OperatorVersion != "" {
+		fmt.Printf("%s  %s\n", styles.Key("Operator:"), styles.Value(server.OperatorVersion))
+	} else {
+		fmt.Printf("%s  %s\n", styles.Key("Operator:"), styles.MutedStyle.Render("not found"))
+	}
+	crdVersions := "not installed"
+	if len(server.CRDVersions) > 0 {
+		crdVersions = strings.Join(server.CRDVersions, ", ")
+	}
+	fmt.Printf("%s  %s\n", styles.Key("CRD Versions:"), styles.Value(crdVersions))
+
+	switch server.Compatibility {
+	case "compatible":
+		fmt.Printf("%s  %s\n", styles.Key("Compatibility:"), styles.SuccessStyle.Render(server.Compatibility))
+	case "degraded":
+		fmt.Printf("%s  %s\n", styles.Key("Compatibility:"), styles.WarningStyle.Render(server.Compatibility))
+	default:
+		fmt.Printf("%s  %s\n", styles.Key("Compatibility:"), styles.ErrorStyle.Render(server.Compatibility))
+	}
+	for _, issue := range server.Issues {
+		fmt.Printf("  %s %s\n", styles.MutedStyle.Render(styles.SymbolBullet), issue.Message)
+	}
+	if len(server.Issues) > 0 {
+		fmt.Println()
+		fmt.Println(styles.Info("Run 'forkspacer check' for remediation hints"))
+	}
+
+	return nil
+}
+
+// getServerVersion runs the preflight against the current cluster
+func getServerVersion() (*serverVersion, error) {
+	restConfig, err := ctrl.GetConfig()
+	if err != nil {
+		return nil, fmt.Errorf("failed to connect to cluster: %w", err)
+	}
+
+	report, err := preflight.Run(context.Background(), restConfig)
+	if err != nil {
+		return nil, fmt.Errorf("failed to connect to cluster: %w", err)
+	}
+
+	server := &serverVersion{
+		KubernetesVersion: report.KubernetesVersion,
+		CRDVersions:       report.ServedVersions,
+		Compatibility:     report.Compatibility(),
+		Issues:            report.Issues,
+	}
+	if report.Operator != nil {
+		server.OperatorVersion = report.Operator.Version
+		server.OperatorImage = report.Operator.Image
+	}
+	return server, nil
 }
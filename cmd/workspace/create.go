@@ -7,6 +7,7 @@ import (
 
 	batchv1 "github.com/forkspacer/forkspacer/api/v1"
 	"github.com/spf13/cobra"
+	"sigs.k8s.io/controller-runtime/pkg/client"
 
 	"github.com/forkspacer/cli/cmd"
 	"github.com/forkspacer/cli/pkg/printer"
@@ -19,9 +20,13 @@ var (
 	createConnectionType   string
 	createHibernationSched string
 	createWakeSched        string
+	createTimezone         string
+	createInteractive      bool
+	createAllow            bool
 	createFromWorkspace    string
 	createMigrateData      bool
 	createWait             bool
+	createTTL              time.Duration
 )
 
 var createCmd = &cobra.Command{
@@ -43,10 +48,25 @@ Examples:
     --hibernation-schedule "0 18 * * *" \
     --wake-schedule "0 8 * * *"
 
+  # Evaluate the schedules in a specific timezone
+  forkspacer workspace create dev-env \
+    --hibernation-schedule "0 18 * * 1-5" \
+    --wake-schedule "0 8 * * 1-5" \
+    --timezone Europe/Berlin
+
+  # Build the schedules by answering a few questions
+  forkspacer workspace create dev-env --interactive
+
   # Fork from existing workspace
   forkspacer workspace create staging \
     --from production \
-    --migrate-data`,
+    --migrate-data
+
+  # Create a preview environment that 'workspace gc' deletes after two days
+  forkspacer workspace create preview-42 --ttl 48h
+
+  # Preview the workspace object without creating it
+  forkspacer workspace create dev-env --dry-run=client -o yaml`,
 	Args: cobra.ExactArgs(1),
 	RunE: runCreate,
 }
@@ -58,18 +78,50 @@ func init() {
 		"Hibernation cron schedule (e.g., '0 18 * * *' for 6 PM daily)")
 	createCmd.Flags().StringVar(&createWakeSched, "wake-schedule", "",
 		"Wake cron schedule (e.g., '0 8 * * *' for 8 AM daily)")
+	createCmd.Flags().StringVar(&createTimezone, "timezone", "",
+		"IANA timezone the schedules are evaluated in (e.g., Europe/Berlin, default UTC)")
+	createCmd.Flags().BoolVarP(&createInteractive, "interactive", "i", false,
+		"Build the hibernation and wake schedules interactively")
+	createCmd.Flags().BoolVar(&createAllow, "allow", false,
+		"Accept contradictory hibernation/wake schedules with a warning")
 	createCmd.Flags().StringVar(&createFromWorkspace, "from", "",
 		"Fork from existing workspace")
 	createCmd.Flags().BoolVar(&createMigrateData, "migrate-data", false,
 		"Migrate PV data when forking (requires --from)")
 	createCmd.Flags().BoolVar(&createWait, "wait", false,
 		"Wait for workspace to become ready")
+	createCmd.Flags().DurationVar(&createTTL, "ttl", 0,
+		"Lifetime after which 'workspace gc' deletes the workspace (e.g., 48h)")
+	cmd.AddDryRunFlag(createCmd)
 }
 
 func runCreate(c *cobra.Command, args []string) error {
 	name := args[0]
 	namespace := cmd.GetNamespace()
 
+	dryRun, err := cmd.GetDryRunStrategy(c)
+	if err != nil {
+		return err
+	}
+	if createTTL < 0 {
+		return fmt.Errorf("--ttl must not be negative")
+	}
+	if createInteractive {
+		if createHibernationSched != "" || createWakeSched != "" || createTimezone != "" {
+			return fmt.Errorf("--interactive cannot be combined with --hibernation-schedule, --wake-schedule or --timezone")
+		}
+
+		pair, err := cmd.RunScheduleBuilder()
+		if err != nil {
+			return err
+		}
+		createHibernationSched, createWakeSched = pair.Sleep, pair.Wake
+	}
+
+	if dryRun != cmd.DryRunNone {
+		return runCreateDryRun(name, namespace, dryRun)
+	}
+
 	// Print header
 	fmt.Println()
 	fmt.Println(styles.TitleStyle.Render(fmt.Sprintf("%s Creating workspace %s", styles.SymbolSparkles, name)))
@@ -86,7 +138,19 @@ func runCreate(c *cobra.Command, args []string) error {
 	}
 	sp.Success("Workspace name is valid")
 
-	// Step 2: Validate hibernation schedule if provided
+	// Step 2: Validate timezone and encode it into the schedules
+	if createTimezone != "" {
+		sp = printer.NewSpinner("Validating timezone")
+		sp.Start()
+
+		if err := applyCreateTimezone(); err != nil {
+			sp.Stop()
+			return err
+		}
+		sp.Success(fmt.Sprintf("Schedules will be evaluated in %s", createTimezone))
+	}
+
+	// Step 3: Validate hibernation schedule if provided
 	if createHibernationSched != "" {
 		sp = printer.NewSpinner("Validating hibernation schedule")
 		sp.Start()
@@ -96,7 +160,7 @@ func runCreate(c *cobra.Command, args []string) error {
 			sp.Stop()
 			return formatCronError(createHibernationSched, err)
 		}
-		sp.Success("Hibernation schedule is valid")
+		sp.Success("Hibernation schedule is valid: " + describeSchedule(createHibernationSched))
 	}
 
 	if createWakeSched != "" {
@@ -112,10 +176,22 @@ func runCreate(c *cobra.Command, args []string) error {
 			sp.Stop()
 			return formatCronError(createWakeSched, err)
 		}
-		sp.Success("Wake schedule is valid")
+		sp.Success("Wake schedule is valid: " + describeSchedule(createWakeSched))
 	}
 
-	// Step 3: Connect to cluster and create service
+	if createHibernationSched != "" {
+		sp = printer.NewSpinner("Analyzing schedule pair")
+		sp.Start()
+
+		analysis, err := checkSchedulePair(createHibernationSched, createWakeSched, createAllow)
+		if err != nil {
+			sp.Stop()
+			return err
+		}
+		sp.Success("Schedule pair simulated over a week: " + awakeSummary(analysis))
+	}
+
+	// Step 4: Connect to cluster and create service
 	sp = printer.NewSpinner("Connecting to Kubernetes cluster")
 	sp.Start()
 
@@ -127,10 +203,10 @@ func runCreate(c *cobra.Command, args []string) error {
 	}
 	sp.Success("Connected to cluster")
 
-	// Step 4: Build workspace input
+	// Step 5: Build workspace input
 	workspaceIn := buildWorkspaceInput(name, namespace)
 
-	// Step 5: Create workspace using api-server service
+	// Step 6: Create workspace using api-server service
 	sp = printer.NewSpinner("Creating workspace resource")
 	sp.Start()
 
@@ -141,7 +217,7 @@ func runCreate(c *cobra.Command, args []string) error {
 	}
 	sp.Success("Workspace resource created")
 
-	// Step 6: Wait for ready (optional)
+	// Step 7: Wait for ready (optional)
 	if createWait {
 		sp = printer.NewSpinner("Waiting for workspace to become ready")
 		sp.Start()
@@ -159,6 +235,94 @@ func runCreate(c *cobra.Command, args []string) error {
 	return nil
 }
 
+// runCreateDryRun validates the flags and builds the workspace without
+// persisting it. Server mode submits it with DryRun: All so admission
+// webhooks and CRD validation still run.
+func runCreateDryRun(name, namespace string, dryRun cmd.DryRunStrategy) error {
+	if err := validateCreateFlags(name); err != nil {
+		return err
+	}
+
+	workspaceIn := buildWorkspaceInput(name, namespace)
+	workspace := workspaceService.BuildWorkspace(workspaceIn)
+
+	if dryRun == cmd.DryRunServer {
+		ctx := context.Background()
+		service, err := workspaceService.NewService()
+		if err != nil {
+			return fmt.Errorf("kubernetes connection failed: %w", err)
+		}
+
+		workspace, err = service.Create(ctx, workspaceIn, client.DryRunAll)
+		if err != nil {
+			return fmt.Errorf("failed to create workspace: %w", err)
+		}
+	}
+
+	return cmd.PrintDryRunResult(workspace, fmt.Sprintf("workspace/%s created", name), dryRun)
+}
+
+// validateCreateFlags runs the same checks as the interactive create steps
+func validateCreateFlags(name string) error {
+	if err := validation.ValidateDNS1123Subdomain(name); err != nil {
+		return formatValidationError(name, err)
+	}
+
+	if err := applyCreateTimezone(); err != nil {
+		return err
+	}
+
+	if createHibernationSched != "" {
+		if err := validation.ValidateCronSchedule(createHibernationSched); err != nil {
+			return formatCronError(createHibernationSched, err)
+		}
+	}
+
+	if createWakeSched != "" {
+		if createHibernationSched == "" {
+			return fmt.Errorf("--wake-schedule requires --hibernation-schedule")
+		}
+		if err := validation.ValidateCronSchedule(createWakeSched); err != nil {
+			return formatCronError(createWakeSched, err)
+		}
+	}
+
+	if createHibernationSched != "" {
+		if _, err := checkSchedulePair(createHibernationSched, createWakeSched, createAllow); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// applyCreateTimezone validates --timezone and encodes it into the schedule
+// flags as a CRON_TZ= prefix
+func applyCreateTimezone() error {
+	if createTimezone == "" {
+		return nil
+	}
+	if createHibernationSched == "" {
+		return fmt.Errorf("--timezone requires --hibernation-schedule")
+	}
+
+	schedule, err := validation.WithTimezone(createHibernationSched, createTimezone)
+	if err != nil {
+		return fmt.Errorf("invalid --timezone: %w", err)
+	}
+	createHibernationSched = schedule
+
+	if createWakeSched != "" {
+		schedule, err := validation.WithTimezone(createWakeSched, createTimezone)
+		if err != nil {
+			return fmt.Errorf("invalid --timezone: %w", err)
+		}
+		createWakeSched = schedule
+	}
+
+	return nil
+}
+
 func buildWorkspaceInput(name, namespace string) workspaceService.WorkspaceCreateInput {
 	workspaceIn := workspaceService.WorkspaceCreateInput{
 		Name:           name,
@@ -189,6 +353,11 @@ func buildWorkspaceInput(name, namespace string) workspaceService.WorkspaceCreat
 		_ = createMigrateData
 	}
 
+	if createTTL > 0 {
+		expiresAt := time.Now().Add(createTTL).Truncate(time.Second)
+		workspaceIn.ExpiresAt = &expiresAt
+	}
+
 	return workspaceIn
 }
 
@@ -234,14 +403,20 @@ func printSuccessSummary(workspace *batchv1.Workspace) {
 
 	if workspace.Spec.AutoHibernation != nil && workspace.Spec.AutoHibernation.Enabled {
 		fmt.Printf("%s  %s\n", styles.Key("Hibernation:"), styles.Value("enabled"))
-		fmt.Printf("  %s  %s\n", styles.Key("Sleep:"), styles.Value(workspace.Spec.AutoHibernation.Schedule))
+		fmt.Printf("  %s  %s\n", styles.Key("Sleep:"), describedSchedule(workspace.Spec.AutoHibernation.Schedule))
 		if workspace.Spec.AutoHibernation.WakeSchedule != nil {
-			fmt.Printf("  %s  %s\n", styles.Key("Wake:"), styles.Value(*workspace.Spec.AutoHibernation.WakeSchedule))
+			fmt.Printf("  %s  %s\n", styles.Key("Wake:"), describedSchedule(*workspace.Spec.AutoHibernation.WakeSchedule))
 		}
+		fmt.Printf("  %s  %s\n", styles.Key("Timezone:"), styles.Value(timezoneLabel(workspace.Spec.AutoHibernation)))
+		printUpcomingTransitions(workspace.Spec.AutoHibernation, defaultUpcomingTransitions, "  ")
 	} else {
 		fmt.Printf("%s  %s\n", styles.Key("Hibernation:"), styles.Value("disabled"))
 	}
 
+	if expiresAt, ok := workspaceService.ExpiresAt(workspace); ok {
+		fmt.Printf("%s  %s\n", styles.Key("Expires:"), styles.Value(expiryLabel(expiresAt, time.Now())))
+	}
+
 	fmt.Println()
 	fmt.Println(styles.SubtitleStyle.Render("Next steps:"))
 	fmt.Printf("  %s %s\n", styles.SymbolArrow, styles.Code(fmt.Sprintf("forkspacer workspace get %s", workspace.Name)))
@@ -271,10 +446,12 @@ func formatValidationError(name string, err error) error {
 
 func formatCronError(schedule string, err error) error {
 	msg := fmt.Sprintf("\n%s\n\n", styles.Error("Invalid cron schedule"))
-	msg += fmt.Sprintf("  The schedule %s is not valid.\n\n", styles.Code(schedule))
+	msg += fmt.Sprintf("  The schedule %s is not valid:\n", styles.Code(schedule))
+	msg += fmt.Sprintf("    %s\n\n", styles.MutedStyle.Render(err.Error()))
 	msg += fmt.Sprintf("  %s\n", styles.Key("Common schedules:"))
-	for schedule, desc := range validation.CronExamples() {
-		msg += fmt.Sprintf("    %s %-20s %s\n", styles.SymbolBullet, styles.Code(schedule), desc)
+	for _, example := range validation.CronExamples() {
+		description, _ := validation.DescribeSchedule(example)
+		msg += fmt.Sprintf("    %s %-20s %s\n", styles.SymbolBullet, styles.Code(example), description)
 	}
 	msg += fmt.Sprintf("\n  %s https://crontab.guru\n", styles.Key("Learn more:"))
 
@@ -3,64 +3,155 @@ package workspace
 import (
 	"context"
 	"fmt"
+	"time"
 
 	"github.com/forkspacer/cli/cmd"
+	"github.com/forkspacer/cli/pkg/batch"
+	moduleService "github.com/forkspacer/cli/pkg/module"
 	"github.com/forkspacer/cli/pkg/printer"
 	"github.com/forkspacer/cli/pkg/styles"
 	workspaceService "github.com/forkspacer/cli/pkg/workspace"
+	batchv1 "github.com/forkspacer/forkspacer/api/v1"
 	"github.com/spf13/cobra"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"sigs.k8s.io/controller-runtime/pkg/client"
 )
 
+// Cascade strategies for workspace deletion
+const (
+	cascadeForeground = "foreground"
+	cascadeBackground = "background"
+)
+
+// deleteWaitTimeout bounds how long --wait and --cascade=foreground wait for finalizers
+const deleteWaitTimeout = 5 * time.Minute
+
 var (
-	deleteForce bool
+	deleteForce   bool
+	deleteCascade string
+	deleteWait    bool
 )
 
 var deleteCmd = &cobra.Command{
-	Use:   "delete [name]",
+	Use:   "delete [name...]",
 	Short: "Delete a workspace",
 	Long: `Delete a Forkspacer workspace and all its modules.
 
-WARNING: This will delete all modules associated with this workspace.
+WARNING: The operator deletes every module that references this workspace.
+The modules are listed before you confirm.
+
+Cascade strategies:
+  • background  Delete the workspace, the operator removes its modules afterwards (default)
+  • foreground  Delete each module and wait for it to uninstall, then delete the workspace
 
 Examples:
   # Delete workspace (with confirmation)
   forkspacer workspace delete dev-env
 
   # Delete without confirmation
-  forkspacer workspace delete dev-env --force`,
-	Args:              cobra.ExactArgs(1),
-	ValidArgsFunction: workspaceNameCompletion,
+  forkspacer workspace delete dev-env --force
+
+  # Delete several workspaces, or all matching a label selector
+  forkspacer workspace delete preview-41 preview-42
+  forkspacer workspace delete -l env=preview --force
+
+  # Remove modules first and wait until everything is gone
+  forkspacer workspace delete dev-env --cascade=foreground --wait
+
+  # Check that the deletion would be admitted without deleting anything
+  forkspacer workspace delete dev-env --dry-run=server`,
+	Args:              cobra.ArbitraryArgs,
+	ValidArgsFunction: workspaceNamesCompletion,
 	RunE:              runDelete,
 }
 
 func init() {
 	deleteCmd.Flags().BoolVarP(&deleteForce, "force", "f", false,
 		"Skip confirmation prompt")
+	deleteCmd.Flags().StringVar(&deleteCascade, "cascade", cascadeBackground,
+		"How dependent modules are handled (foreground|background)")
+	deleteCmd.Flags().BoolVar(&deleteWait, "wait", false,
+		"Wait for finalizers to complete and the workspace to be removed")
+	cmd.AddDryRunFlag(deleteCmd)
+	cmd.AddSelectionFlags(deleteCmd)
+
+	deleteCmd.RegisterFlagCompletionFunc("cascade", func(*cobra.Command, []string, string) ([]string, cobra.ShellCompDirective) {
+		return []string{cascadeForeground, cascadeBackground}, cobra.ShellCompDirectiveNoFileComp
+	})
 }
 
 func runDelete(c *cobra.Command, args []string) error {
-	name := args[0]
 	namespace := cmd.GetNamespace()
 
+	selection, err := cmd.GetSelection(c, args)
+	if err != nil {
+		return err
+	}
+
+	dryRun, err := cmd.GetDryRunStrategy(c)
+	if err != nil {
+		return err
+	}
+
+	propagation, err := cascadePropagation(deleteCascade)
+	if err != nil {
+		return err
+	}
+
 	ctx := context.Background()
 	service, err := workspaceService.NewService()
 	if err != nil {
 		return fmt.Errorf("failed to connect to cluster: %w", err)
 	}
 
+	modService, err := moduleService.NewService()
+	if err != nil {
+		return fmt.Errorf("failed to connect to cluster: %w", err)
+	}
+
+	if !selection.IsSingle() {
+		return runDeleteBatch(ctx, service, modService, selection, namespace, propagation, dryRun)
+	}
+	name := selection.Names[0]
+
 	// Check if workspace exists
 	workspace, err := service.Get(ctx, name, namespace)
 	if err != nil {
 		return err
 	}
 
+	// Find modules that the operator will remove together with the workspace
+	modules, err := modService.ListByWorkspace(ctx, workspace.Name, workspace.Namespace)
+	if err != nil {
+		return fmt.Errorf("failed to list dependent modules: %w", err)
+	}
+
+	deleteOpts := []client.DeleteOption{client.PropagationPolicy(propagation)}
+
+	if dryRun != cmd.DryRunNone {
+		if dryRun == cmd.DryRunServer {
+			if deleteCascade == cascadeForeground {
+				for _, mod := range modules {
+					if err := modService.Delete(ctx, mod.Name, &mod.Namespace, client.DryRunAll); err != nil {
+						return fmt.Errorf("failed to delete module %s/%s: %w", mod.Namespace, mod.Name, err)
+					}
+				}
+			}
+			deleteOpts = append(deleteOpts, client.DryRunAll)
+			if err := service.Delete(ctx, workspace.Name, &workspace.Namespace, deleteOpts...); err != nil {
+				return err
+			}
+		}
+		return cmd.PrintDryRunResult(workspace, fmt.Sprintf("workspace/%s deleted", name), dryRun)
+	}
+
 	// Confirm deletion unless --force
 	if !deleteForce {
 		fmt.Println()
 		fmt.Println(styles.WarningStyle.Render(fmt.Sprintf("⚠  About to delete workspace: %s/%s", namespace, name)))
 		fmt.Println()
-		fmt.Println(styles.MutedStyle.Render("This will also delete all modules in this workspace."))
-		fmt.Println()
+		printDependentModules(modules)
 		fmt.Print("Continue? (y/N): ")
 
 		var response string
@@ -73,17 +164,210 @@ func runDelete(c *cobra.Command, args []string) error {
 		}
 	}
 
+	// Remove modules first so their finalizers uninstall releases before the workspace goes away
+	if deleteCascade == cascadeForeground {
+		for _, mod := range modules {
+			sp := printer.NewSpinner(fmt.Sprintf("Deleting module %s/%s", mod.Namespace, mod.Name))
+			sp.Start()
+
+			if err := modService.Delete(ctx, mod.Name, &mod.Namespace); err != nil && !apierrors.IsNotFound(err) {
+				sp.Error(fmt.Sprintf("Failed to delete module %s/%s", mod.Namespace, mod.Name))
+				return err
+			}
+
+			if err := waitForModuleDeleted(ctx, modService, mod.Name, mod.Namespace, deleteWaitTimeout); err != nil {
+				sp.Error(fmt.Sprintf("Module %s/%s was not removed", mod.Namespace, mod.Name))
+				return err
+			}
+			sp.Success(fmt.Sprintf("Module %s/%s deleted", mod.Namespace, mod.Name))
+		}
+	}
+
 	// Delete workspace
 	sp := printer.NewSpinner("Deleting workspace")
 	sp.Start()
 
-	if err := service.Delete(ctx, workspace.Name, &workspace.Namespace); err != nil {
+	if err := service.Delete(ctx, workspace.Name, &workspace.Namespace, deleteOpts...); err != nil {
 		sp.Error("Failed to delete workspace")
 		return err
 	}
 
+	if deleteWait {
+		sp.UpdateMessage("Waiting for workspace finalizers to complete")
+		if err := waitForWorkspaceDeleted(ctx, service, workspace.Name, workspace.Namespace, deleteWaitTimeout); err != nil {
+			sp.Error("Workspace was not removed")
+			return err
+		}
+	}
+
 	sp.Success(fmt.Sprintf("Workspace %s deleted successfully", name))
 	fmt.Println()
 
 	return nil
 }
+
+// runDeleteBatch deletes every selected workspace concurrently after a single
+// confirmation listing all of them, and prints an aggregated result table
+func runDeleteBatch(
+	ctx context.Context,
+	service *workspaceService.Service,
+	modService *moduleService.Service,
+	selection *cmd.Selection,
+	namespace string,
+	propagation metav1.DeletionPropagation,
+	dryRun cmd.DryRunStrategy,
+) error {
+	targets, err := resolveWorkspaceTargets(ctx, service, selection, namespace)
+	if err != nil {
+		return err
+	}
+
+	// Collect dependent modules up front so they can be shown before
+	// confirming; a single list is grouped by the workspace each module
+	// references
+	modules, err := modService.List(ctx, "")
+	if err != nil {
+		return fmt.Errorf("failed to list dependent modules: %w", err)
+	}
+	dependents := make(map[batch.Target][]batchv1.Module, len(targets))
+	for _, mod := range modules.Items {
+		key := batch.Target{Name: mod.Spec.Workspace.Name, Namespace: mod.Spec.Workspace.Namespace}
+		dependents[key] = append(dependents[key], mod)
+	}
+
+	if dryRun == cmd.DryRunNone && !deleteForce {
+		fmt.Println()
+		fmt.Println(styles.WarningStyle.Render(fmt.Sprintf("⚠  About to delete %d workspace(s):", len(targets))))
+		fmt.Println()
+
+		table := printer.NewTable([]string{"NAME", "NAMESPACE", "MODULES"})
+		for _, t := range targets {
+			table.AddRow([]string{t.Name, t.Namespace, fmt.Sprintf("%d", len(dependents[t]))})
+		}
+		table.Render()
+		fmt.Println()
+		fmt.Println(styles.MutedStyle.Render("Modules referencing these workspaces will also be deleted."))
+		fmt.Println()
+		fmt.Print("Continue? (y/N): ")
+
+		var response string
+		fmt.Scanln(&response)
+		if response != "y" && response != "Y" {
+			fmt.Println()
+			fmt.Println(styles.Info("Deletion cancelled"))
+			fmt.Println()
+			return nil
+		}
+	}
+
+	results := batch.Run(ctx, targets, selection.Concurrency, func(ctx context.Context, t batch.Target) (string, error) {
+		modules := dependents[t]
+		if dryRun == cmd.DryRunClient {
+			return fmt.Sprintf("deleted with %d module(s) (client dry run)", len(modules)), nil
+		}
+
+		var dryRunOpts []client.DeleteOption
+		if dryRun == cmd.DryRunServer {
+			dryRunOpts = append(dryRunOpts, client.DryRunAll)
+		}
+
+		if deleteCascade == cascadeForeground {
+			for _, mod := range modules {
+				if err := modService.Delete(ctx, mod.Name, &mod.Namespace, dryRunOpts...); err != nil && !apierrors.IsNotFound(err) {
+					return "", fmt.Errorf("failed to delete module %s/%s: %w", mod.Namespace, mod.Name, err)
+				}
+				if dryRun == cmd.DryRunNone {
+					if err := waitForModuleDeleted(ctx, modService, mod.Name, mod.Namespace, deleteWaitTimeout); err != nil {
+						return "", fmt.Errorf("module %s/%s: %w", mod.Namespace, mod.Name, err)
+					}
+				}
+			}
+		}
+
+		opts := append([]client.DeleteOption{client.PropagationPolicy(propagation)}, dryRunOpts...)
+		if err := service.Delete(ctx, t.Name, &t.Namespace, opts...); err != nil {
+			return "", err
+		}
+
+		if dryRun == cmd.DryRunServer {
+			return fmt.Sprintf("deleted with %d module(s) (server dry run)", len(modules)), nil
+		}
+
+		if deleteWait {
+			if err := waitForWorkspaceDeleted(ctx, service, t.Name, t.Namespace, deleteWaitTimeout); err != nil {
+				return "", err
+			}
+		}
+		return fmt.Sprintf("deleted with %d module(s)", len(modules)), nil
+	})
+
+	return cmd.PrintBatchResults("workspace", results)
+}
+
+// cascadePropagation maps a --cascade value to a Kubernetes deletion propagation policy
+func cascadePropagation(cascade string) (metav1.DeletionPropagation, error) {
+	switch cascade {
+	case cascadeForeground:
+		return metav1.DeletePropagationForeground, nil
+	case cascadeBackground:
+		return metav1.DeletePropagationBackground, nil
+	default:
+		return "", fmt.Errorf("invalid --cascade value %q (expected foreground or background)", cascade)
+	}
+}
+
+// printDependentModules lists the modules that will be removed along with the workspace
+func printDependentModules(modules []batchv1.Module) {
+	if len(modules) == 0 {
+		fmt.Println(styles.MutedStyle.Render("No modules reference this workspace."))
+		fmt.Println()
+		return
+	}
+
+	fmt.Println(styles.MutedStyle.Render(fmt.Sprintf("The following %d module(s) will also be deleted:", len(modules))))
+	table := printer.NewTable([]string{"NAME", "NAMESPACE", "PHASE", "HIBERNATED"})
+	for _, mod := range modules {
+		table.AddRow([]string{
+			mod.Name,
+			mod.Namespace,
+			string(mod.Status.Phase),
+			fmt.Sprintf("%t", mod.Spec.Hibernated),
+		})
+	}
+	table.Render()
+	fmt.Println()
+}
+
+func waitForWorkspaceDeleted(ctx context.Context, service *workspaceService.Service, name, namespace string, timeout time.Duration) error {
+	return waitForDeleted(ctx, timeout, "workspace", func() error {
+		_, err := service.Get(ctx, name, namespace)
+		return err
+	})
+}
+
+func waitForModuleDeleted(ctx context.Context, service *moduleService.Service, name, namespace string, timeout time.Duration) error {
+	return waitForDeleted(ctx, timeout, "module", func() error {
+		_, err := service.Get(ctx, name, namespace)
+		return err
+	})
+}
+
+// waitForDeleted polls get until it reports NotFound or the timeout elapses
+func waitForDeleted(ctx context.Context, timeout time.Duration, kind string, get func() error) error {
+	ticker := time.NewTicker(2 * time.Second)
+	defer ticker.Stop()
+
+	timeoutCh := time.After(timeout)
+
+	for {
+		select {
+		case <-timeoutCh:
+			return fmt.Errorf("timeout waiting for %s to be deleted", kind)
+		case <-ticker.C:
+			err := get()
+			if apierrors.IsNotFound(err) {
+				return nil
+			}
+		}
+	}
+}
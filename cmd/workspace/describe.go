@@ -0,0 +1,226 @@
+package workspace
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"strconv"
+	"time"
+
+	batchv1 "github.com/forkspacer/forkspacer/api/v1"
+	"github.com/spf13/cobra"
+	corev1 "k8s.io/api/core/v1"
+
+	"github.com/forkspacer/cli/cmd"
+	moduleService "github.com/forkspacer/cli/pkg/module"
+	"github.com/forkspacer/cli/pkg/printer"
+	"github.com/forkspacer/cli/pkg/styles"
+	workspaceService "github.com/forkspacer/cli/pkg/workspace"
+)
+
+var describeCmd = &cobra.Command{
+	Use:   "describe [name]",
+	Short: "Show a workspace with its modules, conditions and events",
+	Long: `Show a workspace in detail to understand why it is not ready.
+
+In addition to 'workspace get', describe lists the status conditions, the
+modules that reference the workspace with their phases and messages, and the
+Kubernetes events involving the workspace. The first failing component is
+highlighted at the top.
+
+Examples:
+  # Describe a workspace
+  forkspacer workspace describe dev-env
+
+  # Machine readable output including modules and events
+  forkspacer workspace describe dev-env -o yaml`,
+	Args:              cobra.ExactArgs(1),
+	ValidArgsFunction: workspaceNameCompletion,
+	RunE:              runDescribe,
+}
+
+// describedModule summarizes a module that references the workspace
+type describedModule struct {
+	Name       string `json:"name"`
+	Namespace  string `json:"namespace"`
+	Phase      string `json:"phase"`
+	Hibernated bool   `json:"hibernated"`
+	Message    string `json:"message,omitempty"`
+}
+
+// workspaceDescription is the json/yaml representation of a described workspace
+type workspaceDescription struct {
+	Workspace *batchv1.Workspace `json:"workspace"`
+	Modules   []describedModule  `json:"modules"`
+	Events    []corev1.Event     `json:"events"`
+	Problem   *printer.Problem   `json:"problem,omitempty"`
+}
+
+func runDescribe(c *cobra.Command, args []string) error {
+	name := args[0]
+	namespace := cmd.GetNamespace()
+
+	ctx := context.Background()
+	service, err := workspaceService.NewService()
+	if err != nil {
+		return fmt.Errorf("failed to connect to cluster: %w", err)
+	}
+	modService, err := moduleService.NewService()
+	if err != nil {
+		return fmt.Errorf("failed to connect to cluster: %w", err)
+	}
+
+	workspace, err := service.Get(ctx, name, namespace)
+	if err != nil {
+		return err
+	}
+
+	modules, err := modService.ListByWorkspace(ctx, workspace.Name, workspace.Namespace)
+	if err != nil {
+		return fmt.Errorf("failed to list modules: %w", err)
+	}
+	sort.Slice(modules, func(i, j int) bool {
+		if modules[i].Namespace != modules[j].Namespace {
+			return modules[i].Namespace < modules[j].Namespace
+		}
+		return modules[i].Name < modules[j].Name
+	})
+
+	events, err := service.Events(ctx, workspace)
+	if err != nil {
+		return fmt.Errorf("failed to list events: %w", err)
+	}
+	printer.SortEvents(events)
+
+	description := workspaceDescription{
+		Workspace: workspace,
+		Modules:   make([]describedModule, 0, len(modules)),
+		Events:    events,
+		Problem:   workspaceProblem(workspace, modules, events),
+	}
+	for _, mod := range modules {
+		described := describedModule{
+			Name:       mod.Name,
+			Namespace:  mod.Namespace,
+			Phase:      string(mod.Status.Phase),
+			Hibernated: mod.Spec.Hibernated,
+		}
+		if mod.Status.Message != nil {
+			described.Message = *mod.Status.Message
+		}
+		description.Modules = append(description.Modules, described)
+	}
+
+	if cmd.IsStructuredOutput() {
+		return printer.PrintObject(description, cmd.GetOutput())
+	}
+
+	fmt.Println()
+	fmt.Println(styles.TitleStyle.Render(fmt.Sprintf("Workspace: %s", workspace.Name)))
+	fmt.Println()
+	printer.PrintProblem(description.Problem)
+	fmt.Println()
+
+	fmt.Println(styles.KeyStyle.Render("Overview"))
+	fmt.Printf("  %s  %s\n", styles.Key("Namespace:"), styles.Value(workspace.Namespace))
+	fmt.Printf("  %s  %s\n", styles.Key("Phase:"), styles.Value(string(workspace.Status.Phase)))
+	fmt.Printf("  %s  %t\n", styles.Key("Ready:"), workspace.Status.Ready)
+	fmt.Printf("  %s  %t\n", styles.Key("Hibernated:"), workspace.Spec.Hibernated)
+	fmt.Printf("  %s  %s\n", styles.Key("Connection:"), styles.Value(string(workspace.Spec.Connection.Type)))
+	if workspace.Spec.From != nil {
+		fmt.Printf("  %s  %s\n", styles.Key("Forked From:"),
+			styles.Value(workspace.Spec.From.Namespace+"/"+workspace.Spec.From.Name))
+	}
+	if ah := workspace.Spec.AutoHibernation; ah != nil && ah.Enabled {
+		fmt.Printf("  %s  %s\n", styles.Key("Sleep Schedule:"), describedSchedule(ah.Schedule))
+		if ah.WakeSchedule != nil {
+			fmt.Printf("  %s  %s\n", styles.Key("Wake Schedule:"), describedSchedule(*ah.WakeSchedule))
+		}
+	}
+	if expiresAt, ok := workspaceService.ExpiresAt(workspace); ok {
+		fmt.Printf("  %s  %s\n", styles.Key("Expires:"), styles.Value(expiryLabel(expiresAt, time.Now())))
+	}
+	if workspace.Status.LastActivity != nil {
+		fmt.Printf("  %s  %s\n", styles.Key("Last Activity:"),
+			styles.Value(workspace.Status.LastActivity.Format("2006-01-02 15:04:05")))
+	}
+	if workspace.Status.Message != nil {
+		fmt.Printf("  %s  %s\n", styles.Key("Message:"), styles.Value(*workspace.Status.Message))
+	}
+	fmt.Println()
+
+	fmt.Println(styles.KeyStyle.Render("Conditions"))
+	printer.PrintConditions(workspace.Status.Conditions)
+	fmt.Println()
+
+	fmt.Println(styles.KeyStyle.Render(fmt.Sprintf("Modules (%d)", len(description.Modules))))
+	if len(description.Modules) == 0 {
+		fmt.Println("  " + styles.MutedStyle.Render("<none>"))
+	} else {
+		table := printer.NewTable([]string{"NAME", "NAMESPACE", "PHASE", "HIBERNATED", "MESSAGE"})
+		for _, mod := range description.Modules {
+			phase := mod.Phase
+			if phase == string(batchv1.ModulePhaseFailed) {
+				phase = styles.ErrorStyle.Render(phase)
+			}
+			table.AddRow([]string{mod.Name, mod.Namespace, phase, strconv.FormatBool(mod.Hibernated), mod.Message})
+		}
+		table.Render()
+	}
+	fmt.Println()
+
+	fmt.Println(styles.KeyStyle.Render("Events"))
+	printer.PrintEvents(events)
+	fmt.Println()
+
+	return nil
+}
+
+// workspaceProblem returns the first failing component of a workspace: the
+// workspace itself, an unhealthy condition, a failed module, or the most
+// recent warning event
+func workspaceProblem(workspace *batchv1.Workspace, modules []batchv1.Module, events []corev1.Event) *printer.Problem {
+	component := fmt.Sprintf("Workspace %s/%s", workspace.Namespace, workspace.Name)
+
+	if workspace.Status.Phase == batchv1.WorkspacePhaseFailed {
+		message := "workspace failed"
+		if workspace.Status.Message != nil {
+			message = *workspace.Status.Message
+		}
+		return &printer.Problem{Component: component, Message: message}
+	}
+
+	for _, c := range workspace.Status.Conditions {
+		if printer.ConditionUnhealthy(c) {
+			return &printer.Problem{
+				Component: fmt.Sprintf("%s condition %s", component, c.Type),
+				Message:   fmt.Sprintf("%s: %s", c.Reason, c.Message),
+			}
+		}
+	}
+
+	for _, mod := range modules {
+		if mod.Status.Phase != batchv1.ModulePhaseFailed {
+			continue
+		}
+		message := "module failed"
+		if mod.Status.Message != nil {
+			message = *mod.Status.Message
+		}
+		return &printer.Problem{
+			Component: fmt.Sprintf("Module %s/%s", mod.Namespace, mod.Name),
+			Message:   message,
+		}
+	}
+
+	if workspace.Status.Ready {
+		return nil
+	}
+	if event, ok := printer.LatestWarning(events); ok {
+		return &printer.Problem{
+			Component: fmt.Sprintf("%s event %s", component, event.Reason),
+			Message:   event.Message,
+		}
+	}
+	return nil
+}
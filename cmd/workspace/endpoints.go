@@ -0,0 +1,186 @@
+package workspace
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"sort"
+	"strings"
+
+	"github.com/spf13/cobra"
+
+	"github.com/forkspacer/cli/cmd"
+	moduleService "github.com/forkspacer/cli/pkg/module"
+	"github.com/forkspacer/cli/pkg/printer"
+	"github.com/forkspacer/cli/pkg/styles"
+	workspaceService "github.com/forkspacer/cli/pkg/workspace"
+)
+
+var endpointsCmd = &cobra.Command{
+	Use:   "endpoints [name]",
+	Short: "List the services and ingresses of a workspace's modules",
+	Long: `List the services and ingresses of every module in a workspace.
+
+They are found through the app.kubernetes.io/instance label Helm charts put
+on the objects of a release. Modules without a Helm release are skipped.
+
+Output formats: table (default), json, yaml, and for scripts env (shell
+export statements) and dotenv (.env file). Variable names combine the module
+name with the service or ingress name minus the release prefix, e.g. service
+redis-master of module redis sets REDIS_MASTER_HOST and REDIS_MASTER_PORT;
+ingresses set <PREFIX>_URL.
+
+Examples:
+  # Show where the modules of a workspace can be reached
+  forkspacer workspace endpoints dev-env
+
+  # Point a test suite at the workspace
+  eval $(forkspacer workspace endpoints dev-env -o env)
+
+  # Write a .env file
+  forkspacer workspace endpoints dev-env -o dotenv > .env`,
+	Args:              cobra.ExactArgs(1),
+	ValidArgsFunction: workspaceNameCompletion,
+	RunE:              runEndpoints,
+}
+
+func runEndpoints(c *cobra.Command, args []string) error {
+	name := args[0]
+	namespace := cmd.GetNamespace()
+
+	output := cmd.GetOutput()
+	switch output {
+	case "table", "wide", "json", "yaml", "env", "dotenv":
+	default:
+		return fmt.Errorf("unsupported output format %q (expected table, json, yaml, env or dotenv)", output)
+	}
+
+	ctx := context.Background()
+	service, err := workspaceService.NewService()
+	if err != nil {
+		return fmt.Errorf("failed to connect to cluster: %w", err)
+	}
+	modService, err := moduleService.NewService()
+	if err != nil {
+		return fmt.Errorf("failed to connect to cluster: %w", err)
+	}
+
+	workspace, err := service.Get(ctx, name, namespace)
+	if err != nil {
+		return err
+	}
+	if err := workspaceService.CheckLocal(workspace); err != nil {
+		return err
+	}
+
+	modules, err := modService.ListByWorkspace(ctx, workspace.Name, workspace.Namespace)
+	if err != nil {
+		return fmt.Errorf("failed to list modules: %w", err)
+	}
+	sort.Slice(modules, func(i, j int) bool {
+		return modules[i].Name < modules[j].Name
+	})
+
+	endpoints := []moduleService.Endpoint{}
+	for i := range modules {
+		mod := &modules[i]
+		if _, _, ok := moduleService.ReleaseName(mod); !ok {
+			if cmd.IsVerbose() {
+				// stderr keeps env and dotenv output clean for eval
+				fmt.Fprintln(os.Stderr, styles.MutedStyle.Render(fmt.Sprintf("Skipping module %s without a Helm release", mod.Name)))
+			}
+			continue
+		}
+
+		moduleEndpoints, err := modService.Endpoints(ctx, mod)
+		if err != nil {
+			return fmt.Errorf("failed to list endpoints of module %s: %w", mod.Name, err)
+		}
+		endpoints = append(endpoints, moduleEndpoints...)
+	}
+
+	switch output {
+	case "json", "yaml":
+		return printer.PrintObject(endpoints, output)
+	case "env":
+		for _, v := range moduleService.EndpointEnv(endpoints) {
+			fmt.Printf("export %s=%s\n", v.Name, shellQuote(v.Value))
+		}
+		return nil
+	case "dotenv":
+		for _, v := range moduleService.EndpointEnv(endpoints) {
+			fmt.Printf("%s=%s\n", v.Name, dotenvQuote(v.Value))
+		}
+		return nil
+	}
+
+	if len(endpoints) == 0 {
+		fmt.Println()
+		fmt.Println(styles.MutedStyle.Render(fmt.Sprintf("No services or ingresses found for the modules of workspace %s", name)))
+		fmt.Println()
+		return nil
+	}
+
+	fmt.Println()
+	table := printer.NewTable([]string{"MODULE", "KIND", "NAME", "ADDRESS", "PORTS", "EXTERNAL"})
+	for _, endpoint := range endpoints {
+		address := endpoint.Host
+		if endpoint.Kind == moduleService.EndpointKindIngress {
+			address = strings.Join(endpoint.URLs, ", ")
+		}
+		table.AddRow([]string{
+			endpoint.Module,
+			endpoint.Kind,
+			endpoint.Name,
+			valueOrDash(address),
+			valueOrDash(formatEndpointPorts(endpoint.Ports)),
+			valueOrDash(strings.Join(endpoint.External, ", ")),
+		})
+	}
+	table.Render()
+	fmt.Println()
+
+	if workspace.Spec.Hibernated {
+		fmt.Println(styles.Info(fmt.Sprintf("Workspace %s is hibernated; wake it before connecting", name)))
+		fmt.Println()
+	}
+	return nil
+}
+
+// formatEndpointPorts formats ports like kubectl get services, e.g. 80:30080/TCP
+func formatEndpointPorts(ports []moduleService.EndpointPort) string {
+	formatted := make([]string, len(ports))
+	for i, port := range ports {
+		if port.NodePort != 0 {
+			formatted[i] = fmt.Sprintf("%d:%d/%s", port.Port, port.NodePort, port.Protocol)
+		} else {
+			formatted[i] = fmt.Sprintf("%d/%s", port.Port, port.Protocol)
+		}
+	}
+	return strings.Join(formatted, ",")
+}
+
+// valueOrDash returns "-" for empty table cells
+func valueOrDash(value string) string {
+	if value == "" {
+		return "-"
+	}
+	return value
+}
+
+// shellQuote quotes a value for POSIX shells
+func shellQuote(value string) string {
+	return "'" + strings.ReplaceAll(value, "'", `'\''`) + "'"
+}
+
+// dotenvQuote quotes a value for .env files when it contains characters
+// dotenv parsers treat specially
+func dotenvQuote(value string) string {
+	if !strings.ContainsAny(value, " \t#\"'$\\") {
+		return value
+	}
+	value = strings.ReplaceAll(value, `\`, `\\`)
+	value = strings.ReplaceAll(value, `"`, `\"`)
+	value = strings.ReplaceAll(value, `$`, `\$`)
+	return `"` + value + `"`
+}
@@ -0,0 +1,149 @@
+package workspace
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/forkspacer/cli/cmd"
+	"github.com/forkspacer/cli/pkg/batch"
+	"github.com/forkspacer/cli/pkg/styles"
+	workspaceService "github.com/forkspacer/cli/pkg/workspace"
+	batchv1 "github.com/forkspacer/forkspacer/api/v1"
+	"github.com/spf13/cobra"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+)
+
+var (
+	expireAllNamespaces bool
+)
+
+var expireCmd = &cobra.Command{
+	Use:   "expire",
+	Short: "Apply ended temporary wakes and snoozes",
+	Long: `Sweep workspaces for time-limited changes that have ended:
+
+  • Workspaces woken with 'workspace wake --for' are hibernated again
+  • Workspaces snoozed with 'workspace snooze' get their saved
+    auto-hibernation schedule restored
+
+The command is a one-shot sweep, suitable for running periodically from a
+Kubernetes CronJob. It exits non-zero if any workspace could not be updated.
+
+Examples:
+  # Sweep the current namespace
+  forkspacer workspace expire
+
+  # Sweep every namespace and show what would change
+  forkspacer workspace expire -A --dry-run=client
+
+  # Run every 5 minutes from a CronJob container
+  #   schedule: "*/5 * * * *"
+  #   args: ["workspace", "expire", "--all-namespaces"]`,
+	RunE: runExpire,
+}
+
+func init() {
+	expireCmd.Flags().BoolVarP(&expireAllNamespaces, "all-namespaces", "A", false,
+		"Sweep workspaces across all namespaces")
+	cmd.AddDryRunFlag(expireCmd)
+}
+
+func runExpire(c *cobra.Command, args []string) error {
+	namespace := cmd.GetNamespace()
+	if expireAllNamespaces {
+		namespace = "" // Empty means all namespaces
+	}
+
+	dryRun, err := cmd.GetDryRunStrategy(c)
+	if err != nil {
+		return err
+	}
+
+	ctx := context.Background()
+	service, err := workspaceService.NewService()
+	if err != nil {
+		return fmt.Errorf("failed to connect to cluster: %w", err)
+	}
+
+	workspaces, err := service.List(ctx, namespace)
+	if err != nil {
+		return err
+	}
+
+	now := time.Now()
+	var targets []batch.Target
+	for _, ws := range workspaces.Items {
+		if wakeExpired(&ws, now) || snoozeExpired(&ws, now) {
+			targets = append(targets, batch.Target{Name: ws.Name, Namespace: ws.Namespace})
+		}
+	}
+
+	if len(targets) == 0 {
+		fmt.Println()
+		fmt.Println(styles.MutedStyle.Render("No expired temporary wakes or snoozes found"))
+		fmt.Println()
+		return nil
+	}
+
+	var opts []client.UpdateOption
+	if dryRun == cmd.DryRunServer {
+		opts = append(opts, client.DryRunAll)
+	}
+
+	results := batch.Run(ctx, targets, batch.DefaultConcurrency, func(ctx context.Context, t batch.Target) (string, error) {
+		workspace, err := service.Get(ctx, t.Name, t.Namespace)
+		if err != nil {
+			return "", err
+		}
+
+		var actions []string
+
+		// Restore the schedule saved by an ended snooze
+		if snoozeExpired(workspace, now) {
+			if dryRun != cmd.DryRunClient {
+				if _, err := service.Unsnooze(ctx, t.Name, t.Namespace, opts...); err != nil {
+					return "", err
+				}
+			}
+			actions = append(actions, "schedule restored")
+		}
+
+		if wakeExpired(workspace, now) {
+			// Hibernated in the meantime, only the stale annotation is left
+			if workspace.Spec.Hibernated {
+				if dryRun != cmd.DryRunClient {
+					if _, err := service.RemoveAnnotations(ctx, t.Name, t.Namespace,
+						[]string{workspaceService.AnnotationWakeUntil}, opts...); err != nil {
+						return "", err
+					}
+				}
+				actions = append(actions, "expiry cleared")
+			} else {
+				if dryRun != cmd.DryRunClient {
+					if _, err := service.SetHibernation(ctx, t.Name, t.Namespace, true, opts...); err != nil {
+						return "", err
+					}
+				}
+				actions = append(actions, "hibernated")
+			}
+		}
+
+		return dryRunMessage(strings.Join(actions, ", "), dryRun), nil
+	})
+
+	return cmd.PrintBatchResults("workspace", results)
+}
+
+// wakeExpired reports whether the workspace has a temporary wake that has ended
+func wakeExpired(workspace *batchv1.Workspace, now time.Time) bool {
+	until, ok := workspaceService.WakeUntil(workspace)
+	return ok && !now.Before(until)
+}
+
+// snoozeExpired reports whether the workspace has a snooze that has ended
+func snoozeExpired(workspace *batchv1.Workspace, now time.Time) bool {
+	until, ok := workspaceService.SnoozeUntil(workspace)
+	return ok && !now.Before(until)
+}
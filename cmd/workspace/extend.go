@@ -0,0 +1,163 @@
+package workspace
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	batchv1 "github.com/forkspacer/forkspacer/api/v1"
+	"github.com/spf13/cobra"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	"github.com/forkspacer/cli/cmd"
+	"github.com/forkspacer/cli/pkg/printer"
+	"github.com/forkspacer/cli/pkg/styles"
+	workspaceService "github.com/forkspacer/cli/pkg/workspace"
+)
+
+var (
+	extendBy    time.Duration
+	extendUntil string
+	extendNever bool
+)
+
+var extendCmd = &cobra.Command{
+	Use:   "extend [name]",
+	Short: "Extend the lifetime of a workspace",
+	Long: `Move the expiry of a workspace created with --ttl.
+
+--by adds to the current expiry, or to the current time if the workspace has
+already expired. --until sets the expiry to a given time and accepts the
+same values as 'workspace snooze --until'. --never removes the expiry so
+'workspace gc' keeps the workspace.
+
+A workspace without a TTL is never deleted by 'workspace gc', so giving it
+one requires an explicit --by or --until.
+
+Examples:
+  # Keep a preview environment for another day
+  forkspacer workspace extend preview-42
+
+  # Keep it for another week
+  forkspacer workspace extend preview-42 --by 168h
+
+  # Keep it until Friday evening
+  forkspacer workspace extend preview-42 --until "2025-10-24 18:00"
+
+  # Keep it forever
+  forkspacer workspace extend preview-42 --never`,
+	Args:              cobra.ExactArgs(1),
+	ValidArgsFunction: workspaceNameCompletion,
+	RunE:              runExtend,
+}
+
+func init() {
+	extendCmd.Flags().DurationVar(&extendBy, "by", 24*time.Hour,
+		"Time to add to the current expiry")
+	extendCmd.Flags().StringVar(&extendUntil, "until", "",
+		"New expiry (e.g. 18:00, \"2025-10-24 18:00\")")
+	extendCmd.Flags().BoolVar(&extendNever, "never", false,
+		"Remove the expiry")
+	cmd.AddDryRunFlag(extendCmd)
+
+	extendCmd.MarkFlagsMutuallyExclusive("by", "until", "never")
+}
+
+func runExtend(c *cobra.Command, args []string) error {
+	name := args[0]
+	namespace := cmd.GetNamespace()
+
+	dryRun, err := cmd.GetDryRunStrategy(c)
+	if err != nil {
+		return err
+	}
+
+	if extendBy <= 0 {
+		return fmt.Errorf("--by must be positive")
+	}
+
+	now := time.Now()
+	var until *time.Time
+	if extendUntil != "" {
+		t, err := parseUntil(extendUntil, now)
+		if err != nil {
+			return err
+		}
+		until = &t
+	}
+
+	ctx := context.Background()
+	service, err := workspaceService.NewService()
+	if err != nil {
+		return fmt.Errorf("failed to connect to cluster: %w", err)
+	}
+
+	workspace, err := service.Get(ctx, name, namespace)
+	if err != nil {
+		return err
+	}
+
+	expiresAt, err := newExpiry(workspace, now, until, c.Flags().Changed("by"))
+	if err != nil {
+		return err
+	}
+
+	if dryRun != cmd.DryRunNone {
+		if dryRun == cmd.DryRunServer {
+			workspace, err = service.SetExpiry(ctx, name, namespace, expiresAt, client.DryRunAll)
+			if err != nil {
+				return err
+			}
+		} else if expiresAt != nil {
+			workspaceService.SetExpiresAt(workspace, *expiresAt)
+		} else {
+			delete(workspace.Annotations, workspaceService.AnnotationExpiresAt)
+		}
+		return cmd.PrintDryRunResult(workspace, fmt.Sprintf("workspace/%s extended", name), dryRun)
+	}
+
+	sp := printer.NewSpinner("Extending workspace lifetime")
+	sp.Start()
+
+	if _, err := service.SetExpiry(ctx, name, namespace, expiresAt); err != nil {
+		sp.Error("Failed to extend workspace")
+		return err
+	}
+
+	if expiresAt == nil {
+		sp.Success(fmt.Sprintf("Workspace %s no longer expires", name))
+		fmt.Println()
+		return nil
+	}
+
+	sp.Success(fmt.Sprintf("Workspace %s now expires %s", name, expiryLabel(*expiresAt, now)))
+	fmt.Println()
+	fmt.Println(styles.MutedStyle.Render("Expired workspaces are deleted by 'forkspacer workspace gc'."))
+	fmt.Println()
+
+	return nil
+}
+
+// newExpiry computes the expiry requested by the flags, nil for --never.
+// The default --by is refused for workspaces without a TTL, which would
+// otherwise be scheduled for deletion by the command meant to keep them.
+func newExpiry(workspace *batchv1.Workspace, now time.Time, until *time.Time, byChanged bool) (*time.Time, error) {
+	if extendNever {
+		return nil, nil
+	}
+	if until != nil {
+		return until, nil
+	}
+
+	current, ok := workspaceService.ExpiresAt(workspace)
+	if !ok && !byChanged {
+		return nil, fmt.Errorf("workspace %s has no TTL; use --by or --until explicitly to give it one", workspace.Name)
+	}
+
+	base := now
+	if ok && current.After(now) {
+		base = current
+	}
+	expiresAt := base.Add(extendBy).Truncate(time.Second)
+	return &expiresAt, nil
+}
@@ -0,0 +1,144 @@
+package workspace
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/spf13/cobra"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/util/duration"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	"github.com/forkspacer/cli/cmd"
+	"github.com/forkspacer/cli/pkg/batch"
+	moduleService "github.com/forkspacer/cli/pkg/module"
+	"github.com/forkspacer/cli/pkg/styles"
+	workspaceService "github.com/forkspacer/cli/pkg/workspace"
+)
+
+var (
+	gcAllNamespaces bool
+)
+
+var gcCmd = &cobra.Command{
+	Use:   "gc",
+	Short: "Delete expired workspaces",
+	Long: `Delete workspaces whose TTL has run out, together with their modules.
+
+Workspaces get a TTL with 'workspace create --ttl' and can be kept longer
+with 'workspace extend'. Workspaces without a TTL are never collected.
+
+The command is a one-shot sweep, suitable for running periodically from a
+Kubernetes CronJob. It exits non-zero if any workspace could not be deleted.
+
+Examples:
+  # Delete expired workspaces in the current namespace
+  forkspacer workspace gc
+
+  # Show what would be deleted across all namespaces
+  forkspacer workspace gc -A --dry-run=client
+
+  # Run hourly from a CronJob container
+  #   schedule: "0 * * * *"
+  #   args: ["workspace", "gc", "--all-namespaces"]`,
+	Args: cobra.NoArgs,
+	RunE: runGC,
+}
+
+func init() {
+	gcCmd.Flags().BoolVarP(&gcAllNamespaces, "all-namespaces", "A", false,
+		"Collect workspaces across all namespaces")
+	cmd.AddDryRunFlag(gcCmd)
+}
+
+func runGC(c *cobra.Command, args []string) error {
+	namespace := cmd.GetNamespace()
+	if gcAllNamespaces {
+		namespace = "" // Empty means all namespaces
+	}
+
+	dryRun, err := cmd.GetDryRunStrategy(c)
+	if err != nil {
+		return err
+	}
+
+	ctx := context.Background()
+	service, err := workspaceService.NewService()
+	if err != nil {
+		return fmt.Errorf("failed to connect to cluster: %w", err)
+	}
+	modService, err := moduleService.NewService()
+	if err != nil {
+		return fmt.Errorf("failed to connect to cluster: %w", err)
+	}
+
+	workspaces, err := service.List(ctx, namespace)
+	if err != nil {
+		return err
+	}
+
+	now := time.Now()
+	var targets []batch.Target
+	for _, ws := range workspaces.Items {
+		if expiresAt, ok := workspaceService.ExpiresAt(&ws); ok && !now.Before(expiresAt) {
+			targets = append(targets, batch.Target{Name: ws.Name, Namespace: ws.Namespace})
+		}
+	}
+
+	if len(targets) == 0 {
+		if cmd.IsStructuredOutput() {
+			return cmd.PrintBatchResults("workspace", nil)
+		}
+		fmt.Println()
+		fmt.Println(styles.MutedStyle.Render("No expired workspaces found"))
+		fmt.Println()
+		return nil
+	}
+
+	results := batch.Run(ctx, targets, batch.DefaultConcurrency, func(ctx context.Context, t batch.Target) (string, error) {
+		modules, err := modService.ListByWorkspace(ctx, t.Name, t.Namespace)
+		if err != nil {
+			return "", fmt.Errorf("failed to list dependent modules: %w", err)
+		}
+
+		if dryRun == cmd.DryRunClient {
+			return dryRunMessage(fmt.Sprintf("deleted with %d module(s)", len(modules)), dryRun), nil
+		}
+
+		var opts []client.DeleteOption
+		if dryRun == cmd.DryRunServer {
+			opts = append(opts, client.DryRunAll)
+		}
+
+		for _, mod := range modules {
+			if err := modService.Delete(ctx, mod.Name, &mod.Namespace, opts...); err != nil && !apierrors.IsNotFound(err) {
+				return "", fmt.Errorf("failed to delete module %s/%s: %w", mod.Namespace, mod.Name, err)
+			}
+		}
+		if err := service.Delete(ctx, t.Name, &t.Namespace, opts...); err != nil {
+			return "", err
+		}
+
+		return dryRunMessage(fmt.Sprintf("deleted with %d module(s)", len(modules)), dryRun), nil
+	})
+
+	return cmd.PrintBatchResults("workspace", results)
+}
+
+// remainingLifetime renders the time left until expiresAt, e.g. "47h"
+func remainingLifetime(expiresAt, now time.Time) string {
+	if !now.Before(expiresAt) {
+		return "expired"
+	}
+	return duration.HumanDuration(expiresAt.Sub(now))
+}
+
+// expiryLabel renders an expiry with the time left or since it passed
+func expiryLabel(expiresAt, now time.Time) string {
+	at := expiresAt.Local().Format("2006-01-02 15:04:05")
+	if !now.Before(expiresAt) {
+		return fmt.Sprintf("%s (expired %s ago)", at, duration.HumanDuration(now.Sub(expiresAt)))
+	}
+	return fmt.Sprintf("%s (in %s)", at, duration.HumanDuration(expiresAt.Sub(now)))
+}
@@ -3,6 +3,7 @@ package workspace
 import (
 	"context"
 	"fmt"
+	"time"
 
 	"github.com/forkspacer/cli/cmd"
 	"github.com/forkspacer/cli/pkg/styles"
@@ -20,16 +21,32 @@ Examples:
   forkspacer workspace get dev-env
 
   # Get workspace in specific namespace
-  forkspacer workspace get dev-env -n production`,
+  forkspacer workspace get dev-env -n production
+
+  # Show the next 8 scheduled sleep/wake transitions
+  forkspacer workspace get dev-env --upcoming 8`,
 	Args:              cobra.ExactArgs(1),
 	ValidArgsFunction: workspaceNameCompletion,
 	RunE:              runGet,
 }
 
+var (
+	getUpcoming int
+)
+
+func init() {
+	getCmd.Flags().IntVar(&getUpcoming, "upcoming", defaultUpcomingTransitions,
+		"Number of upcoming scheduled sleep/wake transitions to show")
+}
+
 func runGet(c *cobra.Command, args []string) error {
 	name := args[0]
 	namespace := cmd.GetNamespace()
 
+	if getUpcoming < 0 {
+		return fmt.Errorf("--upcoming must not be negative")
+	}
+
 	ctx := context.Background()
 	service, err := workspaceService.NewService()
 	if err != nil {
@@ -52,6 +69,9 @@ func runGet(c *cobra.Command, args []string) error {
 	fmt.Printf("  %s  %s\n", styles.Key("Namespace:"), styles.Value(workspace.Namespace))
 	fmt.Printf("  %s  %s\n", styles.Key("UID:"), styles.Value(string(workspace.UID)))
 	fmt.Printf("  %s  %s\n", styles.Key("Created:"), styles.Value(workspace.CreationTimestamp.Format("2006-01-02 15:04:05")))
+	if expiresAt, ok := workspaceService.ExpiresAt(workspace); ok {
+		fmt.Printf("  %s  %s\n", styles.Key("Expires:"), styles.Value(expiryLabel(expiresAt, time.Now())))
+	}
 	fmt.Println()
 
 	// Spec
@@ -70,9 +90,28 @@ func runGet(c *cobra.Command, args []string) error {
 		fmt.Println(styles.KeyStyle.Render("Auto-Hibernation"))
 		fmt.Printf("  %s  %t\n", styles.Key("Enabled:"), workspace.Spec.AutoHibernation.Enabled)
 		if workspace.Spec.AutoHibernation.Enabled {
-			fmt.Printf("  %s  %s\n", styles.Key("Sleep Schedule:"), styles.Value(workspace.Spec.AutoHibernation.Schedule))
+			fmt.Printf("  %s  %s\n", styles.Key("Sleep Schedule:"), describedSchedule(workspace.Spec.AutoHibernation.Schedule))
 			if workspace.Spec.AutoHibernation.WakeSchedule != nil {
-				fmt.Printf("  %s  %s\n", styles.Key("Wake Schedule:"), styles.Value(*workspace.Spec.AutoHibernation.WakeSchedule))
+				fmt.Printf("  %s  %s\n", styles.Key("Wake Schedule:"), describedSchedule(*workspace.Spec.AutoHibernation.WakeSchedule))
+			}
+			fmt.Printf("  %s  %s\n", styles.Key("Timezone:"), styles.Value(timezoneLabel(workspace.Spec.AutoHibernation)))
+			if getUpcoming > 0 {
+				printUpcomingTransitions(workspace.Spec.AutoHibernation, getUpcoming, "  ")
+			}
+		}
+	}
+
+	if until, ok := workspaceService.SnoozeUntil(workspace); ok {
+		fmt.Println()
+		fmt.Println(styles.KeyStyle.Render("Snooze"))
+		fmt.Printf("  %s  %s\n", styles.Key("Snoozed Until:"), styles.Value(until.Local().Format("2006-01-02 15:04:05")))
+		saved, err := workspaceService.SnoozedAutoHibernation(workspace)
+		if err != nil {
+			fmt.Printf("  %s  %s\n", styles.Key("Saved Schedule:"), styles.ErrorStyle.Render(err.Error()))
+		} else if saved != nil {
+			fmt.Printf("  %s  %s\n", styles.Key("Saved Sleep Schedule:"), styles.Value(saved.Schedule))
+			if saved.WakeSchedule != nil {
+				fmt.Printf("  %s  %s\n", styles.Key("Saved Wake Schedule:"), styles.Value(*saved.WakeSchedule))
 			}
 		}
 	}
@@ -111,6 +150,11 @@ func runGet(c *cobra.Command, args []string) error {
 			styles.Value(workspace.Status.HibernatedAt.Format("2006-01-02 15:04:05")))
 	}
 
+	if until, ok := workspaceService.WakeUntil(workspace); ok {
+		fmt.Printf("  %s  %s\n", styles.Key("Awake Until:"),
+			styles.Value(until.Local().Format("2006-01-02 15:04:05")))
+	}
+
 	if workspace.Status.Message != nil {
 		fmt.Printf("  %s  %s\n", styles.Key("Message:"), styles.Value(*workspace.Status.Message))
 	}
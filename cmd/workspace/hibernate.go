@@ -3,17 +3,21 @@ package workspace
 import (
 	"context"
 	"fmt"
+	"time"
 
+	batchv1 "github.com/forkspacer/forkspacer/api/v1"
 	"github.com/spf13/cobra"
+	"sigs.k8s.io/controller-runtime/pkg/client"
 
 	"github.com/forkspacer/cli/cmd"
+	"github.com/forkspacer/cli/pkg/batch"
 	"github.com/forkspacer/cli/pkg/printer"
 	"github.com/forkspacer/cli/pkg/styles"
 	workspaceService "github.com/forkspacer/cli/pkg/workspace"
 )
 
 var hibernateCmd = &cobra.Command{
-	Use:   "hibernate [name]",
+	Use:   "hibernate [name...]",
 	Short: "Hibernate a workspace",
 	Long: `Hibernate a workspace to save resources.
 
@@ -29,22 +33,54 @@ Examples:
   forkspacer workspace hibernate dev-env
 
   # Hibernate workspace in specific namespace
-  forkspacer workspace hibernate staging -n production`,
-	Args:              cobra.ExactArgs(1),
-	ValidArgsFunction: workspaceNameCompletion,
+  forkspacer workspace hibernate staging -n production
+
+  # Hibernate several workspaces at once
+  forkspacer workspace hibernate dev-env staging preview-42
+
+  # Hibernate every workspace labelled team=payments
+  forkspacer workspace hibernate -l team=payments
+
+  # Preview the updated workspace without hibernating it
+  forkspacer workspace hibernate dev-env --dry-run=server -o yaml`,
+	Args:              cobra.ArbitraryArgs,
+	ValidArgsFunction: workspaceNamesCompletion,
 	RunE:              runHibernate,
 }
 
+func init() {
+	cmd.AddDryRunFlag(hibernateCmd)
+	cmd.AddSelectionFlags(hibernateCmd)
+}
+
 func runHibernate(c *cobra.Command, args []string) error {
-	name := args[0]
 	namespace := cmd.GetNamespace()
 
+	selection, err := cmd.GetSelection(c, args)
+	if err != nil {
+		return err
+	}
+
+	dryRun, err := cmd.GetDryRunStrategy(c)
+	if err != nil {
+		return err
+	}
+
 	ctx := context.Background()
 	service, err := workspaceService.NewService()
 	if err != nil {
 		return fmt.Errorf("failed to connect to cluster: %w", err)
 	}
 
+	if !selection.IsSingle() {
+		return runSetHibernationBatch(ctx, service, selection, namespace, true, nil, dryRun)
+	}
+	name := selection.Names[0]
+
+	if dryRun != cmd.DryRunNone {
+		return runSetHibernationDryRun(ctx, service, name, namespace, true, nil, dryRun)
+	}
+
 	// Get workspace
 	sp := printer.NewSpinner("Fetching workspace")
 	sp.Start()
@@ -86,3 +122,109 @@ func runHibernate(c *cobra.Command, args []string) error {
 
 	return nil
 }
+
+// setHibernation hibernates or wakes a workspace. A non-nil wakeUntil turns
+// a wake into a temporary wake that ends at the given time.
+func setHibernation(
+	ctx context.Context,
+	service *workspaceService.Service,
+	name, namespace string,
+	hibernated bool,
+	wakeUntil *time.Time,
+	opts ...client.UpdateOption,
+) (*batchv1.Workspace, error) {
+	if !hibernated && wakeUntil != nil {
+		return service.WakeUntil(ctx, name, namespace, *wakeUntil, opts...)
+	}
+	return service.SetHibernation(ctx, name, namespace, hibernated, opts...)
+}
+
+// runSetHibernationDryRun previews a hibernate or wake operation. Client mode
+// applies the change to the fetched object locally, server mode submits the
+// update with DryRun: All.
+func runSetHibernationDryRun(
+	ctx context.Context,
+	service *workspaceService.Service,
+	name, namespace string,
+	hibernated bool,
+	wakeUntil *time.Time,
+	dryRun cmd.DryRunStrategy,
+) error {
+	var workspace *batchv1.Workspace
+	var err error
+
+	if dryRun == cmd.DryRunServer {
+		workspace, err = setHibernation(ctx, service, name, namespace, hibernated, wakeUntil, client.DryRunAll)
+	} else {
+		workspace, err = service.Get(ctx, name, namespace)
+		if err == nil {
+			workspace.Spec.Hibernated = hibernated
+			delete(workspace.Annotations, workspaceService.AnnotationWakeUntil)
+			if !hibernated && wakeUntil != nil {
+				workspaceService.SetWakeUntil(workspace, *wakeUntil)
+			}
+		}
+	}
+	if err != nil {
+		return err
+	}
+
+	action := "woken"
+	if hibernated {
+		action = "hibernated"
+	}
+	return cmd.PrintDryRunResult(workspace, fmt.Sprintf("workspace/%s %s", name, action), dryRun)
+}
+
+// runSetHibernationBatch hibernates or wakes every selected workspace
+// concurrently and prints an aggregated result table
+func runSetHibernationBatch(
+	ctx context.Context,
+	service *workspaceService.Service,
+	selection *cmd.Selection,
+	namespace string,
+	hibernated bool,
+	wakeUntil *time.Time,
+	dryRun cmd.DryRunStrategy,
+) error {
+	targets, err := resolveWorkspaceTargets(ctx, service, selection, namespace)
+	if err != nil {
+		return err
+	}
+
+	action := "woken"
+	if hibernated {
+		action = "hibernated"
+	} else if wakeUntil != nil {
+		action = "woken until " + wakeUntil.Local().Format("2006-01-02 15:04:05")
+	}
+
+	results := batch.Run(ctx, targets, selection.Concurrency, func(ctx context.Context, t batch.Target) (string, error) {
+		workspace, err := service.Get(ctx, t.Name, t.Namespace)
+		if err != nil {
+			return "", err
+		}
+
+		// Awake workspaces still get the --for deadline
+		if workspace.Spec.Hibernated == hibernated && (hibernated || wakeUntil == nil) {
+			if hibernated {
+				return "already hibernated", nil
+			}
+			return "already awake", nil
+		}
+
+		var opts []client.UpdateOption
+		if dryRun == cmd.DryRunServer {
+			opts = append(opts, client.DryRunAll)
+		}
+
+		if dryRun != cmd.DryRunClient {
+			if _, err := setHibernation(ctx, service, t.Name, t.Namespace, hibernated, wakeUntil, opts...); err != nil {
+				return "", err
+			}
+		}
+		return dryRunMessage(action, dryRun), nil
+	})
+
+	return cmd.PrintBatchResults("workspace", results)
+}
@@ -0,0 +1,363 @@
+package workspace
+
+import (
+	"context"
+	"fmt"
+	"strconv"
+	"time"
+
+	"github.com/spf13/cobra"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/util/duration"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	"github.com/forkspacer/cli/cmd"
+	"github.com/forkspacer/cli/pkg/batch"
+	moduleService "github.com/forkspacer/cli/pkg/module"
+	"github.com/forkspacer/cli/pkg/printer"
+	"github.com/forkspacer/cli/pkg/styles"
+	workspaceService "github.com/forkspacer/cli/pkg/workspace"
+)
+
+var (
+	idleSince         time.Duration
+	idleAllNamespaces bool
+	idleHibernate     bool
+	idleDelete        bool
+	idleForce         bool
+)
+
+var idleCmd = &cobra.Command{
+	Use:   "idle",
+	Short: "Find and reclaim idle workspaces",
+	Long: `List workspaces and modules without activity for longer than a threshold.
+
+A workspace counts as idle when neither it nor any of its modules reported
+activity within --since. Objects that never reported activity are measured
+from their creation time.
+
+With --hibernate the idle workspaces that are still awake are hibernated;
+with --delete the idle workspaces are deleted together with their modules.
+Both ask for confirmation unless --force is given, which makes the command
+suitable for a nightly cleanup job.
+
+Examples:
+  # Workspaces idle for more than 3 days in the current namespace
+  forkspacer workspace idle --since 72h
+
+  # Idle workspaces across all namespaces as JSON
+  forkspacer workspace idle -A -o json
+
+  # Hibernate everything idle for a day, without prompting
+  forkspacer workspace idle -A --since 24h --hibernate --force
+
+  # Show what a cleanup of two-week-old workspaces would delete
+  forkspacer workspace idle -A --since 336h --delete --dry-run=client`,
+	Args: cobra.NoArgs,
+	RunE: runIdle,
+}
+
+func init() {
+	idleCmd.Flags().DurationVar(&idleSince, "since", 72*time.Hour,
+		"Minimum time without activity for a workspace to count as idle")
+	idleCmd.Flags().BoolVarP(&idleAllNamespaces, "all-namespaces", "A", false,
+		"Search workspaces across all namespaces")
+	idleCmd.Flags().BoolVar(&idleHibernate, "hibernate", false,
+		"Hibernate the idle workspaces that are awake")
+	idleCmd.Flags().BoolVar(&idleDelete, "delete", false,
+		"Delete the idle workspaces and their modules")
+	idleCmd.Flags().BoolVarP(&idleForce, "force", "f", false,
+		"Skip confirmation prompt")
+	cmd.AddDryRunFlag(idleCmd)
+
+	idleCmd.MarkFlagsMutuallyExclusive("hibernate", "delete")
+}
+
+// idleWorkspace is a workspace without activity for longer than the threshold
+type idleWorkspace struct {
+	Name         string     `json:"name"`
+	Namespace    string     `json:"namespace"`
+	Hibernated   bool       `json:"hibernated"`
+	HibernatedAt *time.Time `json:"hibernatedAt,omitempty"`
+	LastActivity time.Time  `json:"lastActivity"`
+	IdleFor      string     `json:"idleFor"`
+	Modules      int        `json:"modules"`
+}
+
+// idleModule is a module without activity for longer than the threshold
+type idleModule struct {
+	Name         string    `json:"name"`
+	Namespace    string    `json:"namespace"`
+	Workspace    string    `json:"workspace"`
+	Hibernated   bool      `json:"hibernated"`
+	LastActivity time.Time `json:"lastActivity"`
+	IdleFor      string    `json:"idleFor"`
+}
+
+// idleReport is the json/yaml representation of the idle objects
+type idleReport struct {
+	Since      string          `json:"since"`
+	Workspaces []idleWorkspace `json:"workspaces"`
+	Modules    []idleModule    `json:"modules"`
+}
+
+func runIdle(c *cobra.Command, args []string) error {
+	if idleSince <= 0 {
+		return fmt.Errorf("--since must be positive")
+	}
+
+	namespace := cmd.GetNamespace()
+	if idleAllNamespaces {
+		namespace = "" // Empty means all namespaces
+	}
+
+	dryRun, err := cmd.GetDryRunStrategy(c)
+	if err != nil {
+		return err
+	}
+
+	ctx := context.Background()
+	service, err := workspaceService.NewService()
+	if err != nil {
+		return fmt.Errorf("failed to connect to cluster: %w", err)
+	}
+	modService, err := moduleService.NewService()
+	if err != nil {
+		return fmt.Errorf("failed to connect to cluster: %w", err)
+	}
+
+	report, err := findIdle(ctx, service, modService, namespace, time.Now())
+	if err != nil {
+		return err
+	}
+
+	if !idleHibernate && !idleDelete {
+		if cmd.IsStructuredOutput() {
+			return printer.PrintObject(report, cmd.GetOutput())
+		}
+		printIdleReport(report)
+		return nil
+	}
+
+	if len(report.Workspaces) == 0 {
+		if cmd.IsStructuredOutput() {
+			return cmd.PrintBatchResults("workspace", nil)
+		}
+		printIdleReport(report)
+		return nil
+	}
+
+	action := "hibernate"
+	if idleDelete {
+		action = "delete"
+	}
+
+	if dryRun == cmd.DryRunNone && !idleForce {
+		if cmd.IsStructuredOutput() {
+			return fmt.Errorf("--%s with -o %s requires --force", action, cmd.GetOutput())
+		}
+		printIdleReport(report)
+		fmt.Println(styles.WarningStyle.Render(fmt.Sprintf("⚠  About to %s %d idle workspace(s)", action, len(report.Workspaces))))
+		if idleDelete {
+			fmt.Println(styles.MutedStyle.Render("Modules referencing these workspaces will also be deleted."))
+		}
+		fmt.Println()
+		fmt.Print("Continue? (y/N): ")
+
+		var response string
+		fmt.Scanln(&response)
+		if response != "y" && response != "Y" {
+			fmt.Println()
+			fmt.Println(styles.Info("Cleanup cancelled"))
+			fmt.Println()
+			return nil
+		}
+	}
+
+	targets := make([]batch.Target, 0, len(report.Workspaces))
+	for _, ws := range report.Workspaces {
+		targets = append(targets, batch.Target{Name: ws.Name, Namespace: ws.Namespace})
+	}
+
+	results := batch.Run(ctx, targets, batch.DefaultConcurrency, func(ctx context.Context, t batch.Target) (string, error) {
+		if idleDelete {
+			return deleteIdleWorkspace(ctx, service, t, dryRun)
+		}
+		return hibernateIdleWorkspace(ctx, service, t, dryRun)
+	})
+
+	return cmd.PrintBatchResults("workspace", results)
+}
+
+// findIdle collects the workspaces and modules in namespace whose last
+// activity is older than --since
+func findIdle(
+	ctx context.Context,
+	service *workspaceService.Service,
+	modService *moduleService.Service,
+	namespace string,
+	now time.Time,
+) (*idleReport, error) {
+	workspaces, err := service.List(ctx, namespace)
+	if err != nil {
+		return nil, err
+	}
+
+	// Modules may live in another namespace than their workspace
+	modules, err := modService.List(ctx, "")
+	if err != nil {
+		return nil, fmt.Errorf("failed to list modules: %w", err)
+	}
+
+	report := &idleReport{
+		Since:      idleSince.String(),
+		Workspaces: []idleWorkspace{},
+		Modules:    []idleModule{},
+	}
+	cutoff := now.Add(-idleSince)
+
+	for _, ws := range workspaces.Items {
+		last := activityTime(ws.Status.LastActivity, ws.CreationTimestamp.Time)
+		count := 0
+
+		for _, mod := range modules.Items {
+			if mod.Spec.Workspace.Name != ws.Name || mod.Spec.Workspace.Namespace != ws.Namespace {
+				continue
+			}
+			count++
+
+			modLast := activityTime(mod.Status.LastActivity, mod.CreationTimestamp.Time)
+			if modLast.After(last) {
+				last = modLast
+			}
+			if modLast.Before(cutoff) {
+				report.Modules = append(report.Modules, idleModule{
+					Name:         mod.Name,
+					Namespace:    mod.Namespace,
+					Workspace:    ws.Namespace + "/" + ws.Name,
+					Hibernated:   mod.Spec.Hibernated,
+					LastActivity: modLast,
+					IdleFor:      duration.HumanDuration(now.Sub(modLast)),
+				})
+			}
+		}
+
+		if !last.Before(cutoff) {
+			continue
+		}
+
+		idle := idleWorkspace{
+			Name:         ws.Name,
+			Namespace:    ws.Namespace,
+			Hibernated:   ws.Spec.Hibernated,
+			LastActivity: last,
+			IdleFor:      duration.HumanDuration(now.Sub(last)),
+			Modules:      count,
+		}
+		if ws.Status.HibernatedAt != nil {
+			hibernatedAt := ws.Status.HibernatedAt.Time
+			idle.HibernatedAt = &hibernatedAt
+		}
+		report.Workspaces = append(report.Workspaces, idle)
+	}
+
+	return report, nil
+}
+
+// activityTime returns the recorded activity, or created if there is none
+func activityTime(lastActivity *metav1.Time, created time.Time) time.Time {
+	if lastActivity != nil && !lastActivity.IsZero() {
+		return lastActivity.Time
+	}
+	return created
+}
+
+func printIdleReport(report *idleReport) {
+	fmt.Println()
+	if len(report.Workspaces) == 0 && len(report.Modules) == 0 {
+		fmt.Println(styles.MutedStyle.Render(fmt.Sprintf("No workspaces or modules idle for more than %s", report.Since)))
+		fmt.Println()
+		return
+	}
+
+	if len(report.Workspaces) > 0 {
+		fmt.Println(styles.SubtitleStyle.Render(fmt.Sprintf("Workspaces idle for more than %s:", report.Since)))
+		table := printer.NewTable([]string{"NAME", "NAMESPACE", "HIBERNATED", "LAST ACTIVITY", "IDLE", "MODULES"})
+		for _, ws := range report.Workspaces {
+			hibernated := strconv.FormatBool(ws.Hibernated)
+			if ws.HibernatedAt != nil {
+				hibernated = "since " + ws.HibernatedAt.Local().Format("2006-01-02 15:04")
+			}
+			table.AddRow([]string{
+				ws.Name,
+				ws.Namespace,
+				hibernated,
+				ws.LastActivity.Local().Format("2006-01-02 15:04:05"),
+				ws.IdleFor,
+				strconv.Itoa(ws.Modules),
+			})
+		}
+		table.Render()
+		fmt.Println()
+	}
+
+	if len(report.Modules) > 0 {
+		fmt.Println(styles.SubtitleStyle.Render(fmt.Sprintf("Modules idle for more than %s:", report.Since)))
+		table := printer.NewTable([]string{"NAME", "NAMESPACE", "WORKSPACE", "HIBERNATED", "LAST ACTIVITY", "IDLE"})
+		for _, mod := range report.Modules {
+			table.AddRow([]string{
+				mod.Name,
+				mod.Namespace,
+				mod.Workspace,
+				strconv.FormatBool(mod.Hibernated),
+				mod.LastActivity.Local().Format("2006-01-02 15:04:05"),
+				mod.IdleFor,
+			})
+		}
+		table.Render()
+		fmt.Println()
+	}
+
+	fmt.Println(styles.MutedStyle.Render(fmt.Sprintf("Total: %d idle workspace(s), %d idle module(s)",
+		len(report.Workspaces), len(report.Modules))))
+	fmt.Println()
+}
+
+// hibernateIdleWorkspace hibernates an idle workspace unless it already is
+func hibernateIdleWorkspace(ctx context.Context, service *workspaceService.Service, t batch.Target, dryRun cmd.DryRunStrategy) (string, error) {
+	workspace, err := service.Get(ctx, t.Name, t.Namespace)
+	if err != nil {
+		return "", err
+	}
+	if workspace.Spec.Hibernated {
+		return "already hibernated", nil
+	}
+
+	var opts []client.UpdateOption
+	if dryRun == cmd.DryRunServer {
+		opts = append(opts, client.DryRunAll)
+	}
+	if dryRun != cmd.DryRunClient {
+		if _, err := service.SetHibernation(ctx, t.Name, t.Namespace, true, opts...); err != nil {
+			return "", err
+		}
+	}
+	return dryRunMessage("hibernated", dryRun), nil
+}
+
+// deleteIdleWorkspace deletes an idle workspace; the operator removes the
+// modules that reference it
+func deleteIdleWorkspace(ctx context.Context, service *workspaceService.Service, t batch.Target, dryRun cmd.DryRunStrategy) (string, error) {
+	if dryRun == cmd.DryRunClient {
+		return dryRunMessage("deleted", dryRun), nil
+	}
+
+	var opts []client.DeleteOption
+	if dryRun == cmd.DryRunServer {
+		opts = append(opts, client.DryRunAll)
+	}
+	if err := service.Delete(ctx, t.Name, &t.Namespace, opts...); err != nil {
+		return "", err
+	}
+	return dryRunMessage("deleted", dryRun), nil
+}
@@ -0,0 +1,203 @@
+package workspace
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"strings"
+	"time"
+
+	"github.com/spf13/cobra"
+	"k8s.io/client-go/tools/clientcmd"
+
+	"github.com/forkspacer/cli/cmd"
+	moduleService "github.com/forkspacer/cli/pkg/module"
+	"github.com/forkspacer/cli/pkg/printer"
+	"github.com/forkspacer/cli/pkg/styles"
+	workspaceService "github.com/forkspacer/cli/pkg/workspace"
+)
+
+var (
+	kubeconfigDuration time.Duration
+	kubeconfigReadOnly bool
+	kubeconfigFile     string
+	kubeconfigMerge    bool
+	kubeconfigContext  string
+)
+
+var kubeconfigCmd = &cobra.Command{
+	Use:   "kubeconfig [name]",
+	Short: "Generate a kubeconfig scoped to a workspace",
+	Long: `Generate a kubeconfig whose credentials only reach a workspace.
+
+A ServiceAccount named forkspacer-<workspace> is created (or reused) in the
+workspace's namespace, with a Role and RoleBinding of the same name:
+
+  • In the workspace's namespace: get and watch on the workspace itself. It
+    can not be changed, so hibernating and waking it takes your own
+    credentials; the workspace spec names the kubeconfig secret the operator
+    reads with its cluster-wide rights.
+  • In the namespace of every module of the workspace: get and watch on those
+    modules by name. Other workspaces and their modules are out of reach, and
+    modules can not be listed, created or deleted. Run the command again after
+    adding modules.
+  • In the namespace of every module's Helm release: full access to
+    deployments, statefulsets, replicasets, daemonsets, jobs, cronjobs,
+    services, endpoints, configmaps, persistent volume claims and ingresses;
+    reading pods, logs, events, service accounts and secrets; deleting pods,
+    exec and port-forwarding.
+
+With --read-only every permission is limited to get, list and watch and
+secrets are left out. Secrets and service accounts are never writable.
+Modules released into the workspace's own namespace, or into a namespace the
+modules of another workspace release into as well, get no workload access,
+since the access would extend to the other workspaces. Objects in the
+workspace's namespace are deleted together with the workspace.
+
+The kubeconfig authenticates with a bound token from the TokenRequest API
+that expires after --duration; the API server may issue a shorter one. Run
+the command again for a new token. Deleting the ServiceAccount revokes all
+tokens at once.
+
+The kubeconfig is printed unless --file or --merge is given. Merging adds a
+context named forkspacer-<namespace>-<workspace> to ~/.kube/config (or the
+first file of $KUBECONFIG) without switching to it.
+
+Examples:
+  # Hand a colleague a kubeconfig for one day
+  forkspacer workspace kubeconfig dev-env --file dev-env.kubeconfig
+
+  # Add a read-only context for a week to your kubeconfig
+  forkspacer workspace kubeconfig dev-env --merge --read-only --duration 168h
+
+  # Use it in CI
+  forkspacer workspace kubeconfig preview-42 --duration 1h > "$KUBECONFIG"`,
+	Args:              cobra.ExactArgs(1),
+	ValidArgsFunction: workspaceNameCompletion,
+	RunE:              runKubeconfig,
+}
+
+func init() {
+	kubeconfigCmd.Flags().DurationVar(&kubeconfigDuration, "duration", 24*time.Hour,
+		"Lifetime of the token (e.g. 8h, 168h)")
+	kubeconfigCmd.Flags().BoolVar(&kubeconfigReadOnly, "read-only", false,
+		"Only allow reading the workspace's resources")
+	kubeconfigCmd.Flags().StringVar(&kubeconfigFile, "file", "",
+		"Write the kubeconfig to this file instead of printing it")
+	kubeconfigCmd.Flags().BoolVar(&kubeconfigMerge, "merge", false,
+		"Merge the context into ~/.kube/config (or the first file of $KUBECONFIG)")
+	kubeconfigCmd.Flags().StringVar(&kubeconfigContext, "context", "",
+		"Name of the context, cluster and user (default forkspacer-<namespace>-<name>)")
+	kubeconfigCmd.MarkFlagsMutuallyExclusive("file", "merge")
+}
+
+func runKubeconfig(c *cobra.Command, args []string) error {
+	name := args[0]
+	namespace := cmd.GetNamespace()
+
+	// TokenRequest needs at least ten minutes
+	if kubeconfigDuration < 10*time.Minute {
+		return fmt.Errorf("--duration must be at least 10m")
+	}
+
+	ctx := context.Background()
+	service, err := workspaceService.NewService()
+	if err != nil {
+		return fmt.Errorf("failed to connect to cluster: %w", err)
+	}
+	modService, err := moduleService.NewService()
+	if err != nil {
+		return fmt.Errorf("failed to connect to cluster: %w", err)
+	}
+
+	workspace, err := service.Get(ctx, name, namespace)
+	if err != nil {
+		return err
+	}
+	if err := workspaceService.CheckLocal(workspace); err != nil {
+		return err
+	}
+
+	// Modules of all workspaces are needed to tell which release namespaces
+	// are shared
+	modules, err := modService.List(ctx, "")
+	if err != nil {
+		return fmt.Errorf("failed to list modules: %w", err)
+	}
+	scope := workspaceService.AccessNamespaces(workspace, modules.Items)
+
+	// Status goes to stderr when the kubeconfig itself is printed
+	status := os.Stdout
+	if kubeconfigFile == "" && !kubeconfigMerge {
+		status = os.Stderr
+	}
+	for _, ns := range scope.Templated {
+		fmt.Fprintln(status, styles.Warning(fmt.Sprintf("Skipping templated release namespace %q; only the operator knows its value", ns)))
+	}
+	for _, ns := range scope.Shared {
+		fmt.Fprintln(status, styles.Warning(fmt.Sprintf("Modules released into namespace %s get no workload access; it is shared with other workspaces", ns)))
+	}
+
+	spinner := printer.NewSpinner("Granting access to workspace")
+	spinner.Start()
+	serviceAccount, err := service.GrantAccess(ctx, workspace, scope, kubeconfigReadOnly)
+	if err != nil {
+		spinner.Error("Failed to grant access")
+		return err
+	}
+	token, expiresAt, err := service.RequestToken(ctx, serviceAccount, kubeconfigDuration)
+	if err != nil {
+		spinner.Error("Failed to request token")
+		return err
+	}
+	spinner.Stop()
+
+	contextName := kubeconfigContext
+	if contextName == "" {
+		contextName = fmt.Sprintf("forkspacer-%s-%s", workspace.Namespace, workspace.Name)
+	}
+	config, err := workspaceService.BuildKubeconfig(service.RESTConfig(), contextName, workspace.Namespace, token)
+	if err != nil {
+		return err
+	}
+
+	switch {
+	case kubeconfigMerge:
+		path := workspaceService.DefaultKubeconfigPath()
+		if err := workspaceService.MergeKubeconfig(path, config); err != nil {
+			return err
+		}
+		fmt.Println(styles.Success(fmt.Sprintf("Context %s added to %s", contextName, path)))
+	case kubeconfigFile != "":
+		if err := clientcmd.WriteToFile(*config, kubeconfigFile); err != nil {
+			return fmt.Errorf("failed to write kubeconfig: %w", err)
+		}
+		fmt.Println(styles.Success(fmt.Sprintf("Kubeconfig written to %s", kubeconfigFile)))
+	default:
+		data, err := clientcmd.Write(*config)
+		if err != nil {
+			return fmt.Errorf("failed to encode kubeconfig: %w", err)
+		}
+		os.Stdout.Write(data)
+	}
+
+	access := "edit"
+	if kubeconfigReadOnly {
+		access = "read-only"
+	}
+	fmt.Fprintln(status)
+	fmt.Fprintf(status, "  %s  %s\n", styles.Key("Service account:"), styles.Value(serviceAccount.Namespace+"/"+serviceAccount.Name))
+	fmt.Fprintf(status, "  %s  %s\n", styles.Key("Access:"), styles.Value(access))
+	fmt.Fprintf(status, "  %s  %s\n", styles.Key("Workspace:"), styles.Value(workspace.Namespace+"/"+workspace.Name))
+	releaseNamespaces := "none"
+	if len(scope.ReleaseNamespaces) > 0 {
+		releaseNamespaces = strings.Join(scope.ReleaseNamespaces, ", ")
+	}
+	fmt.Fprintf(status, "  %s  %s\n", styles.Key("Release namespaces:"), styles.Value(releaseNamespaces))
+	fmt.Fprintf(status, "  %s  %s\n", styles.Key("Expires:"), styles.Value(printer.FormatInstant(expiresAt)))
+	if kubeconfigMerge {
+		fmt.Println()
+		fmt.Printf("  %s %s\n", styles.SymbolArrow, styles.Code("kubectl config use-context "+contextName))
+	}
+	return nil
+}
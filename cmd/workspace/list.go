@@ -3,11 +3,14 @@ package workspace
 import (
 	"context"
 	"fmt"
+	"time"
 
 	"github.com/forkspacer/cli/cmd"
 	"github.com/forkspacer/cli/pkg/printer"
 	"github.com/forkspacer/cli/pkg/styles"
+	"github.com/forkspacer/cli/pkg/validation"
 	workspaceService "github.com/forkspacer/cli/pkg/workspace"
+	batchv1 "github.com/forkspacer/forkspacer/api/v1"
 	"github.com/spf13/cobra"
 )
 
@@ -29,7 +32,10 @@ Examples:
   forkspacer workspace list -n production
 
   # List across all namespaces
-  forkspacer workspace list --all-namespaces`,
+  forkspacer workspace list --all-namespaces
+
+  # Include the next scheduled sleep/wake and the current awake window
+  forkspacer workspace list -o wide`,
 	RunE: runList,
 }
 
@@ -67,7 +73,13 @@ func runList(c *cobra.Command, args []string) error {
 
 	// Print table
 	fmt.Println()
-	table := printer.NewTable([]string{"NAME", "NAMESPACE", "PHASE", "READY", "HIBERNATED", "LAST ACTIVITY"})
+	wide := cmd.GetOutput() == "wide"
+	headers := []string{"NAME", "NAMESPACE", "PHASE", "READY", "HIBERNATED", "LAST ACTIVITY", "TTL"}
+	if wide {
+		headers = append(headers, "NEXT SLEEP", "NEXT WAKE", "WINDOW")
+	}
+	table := printer.NewTable(headers)
+	now := time.Now()
 
 	for _, ws := range workspaces.Items {
 		hibernated := "false"
@@ -80,18 +92,28 @@ func runList(c *cobra.Command, args []string) error {
 			lastActivity = ws.Status.LastActivity.Format("2006-01-02 15:04:05")
 		}
 
+		ttl := "-"
+		if expiresAt, ok := workspaceService.ExpiresAt(&ws); ok {
+			ttl = remainingLifetime(expiresAt, now)
+		}
+
 		// Color code the phase
 		phase := string(ws.Status.Phase)
 		ready := fmt.Sprintf("%t", ws.Status.Ready)
 
-		table.AddRow([]string{
+		row := []string{
 			ws.Name,
 			ws.Namespace,
 			phase,
 			ready,
 			hibernated,
 			lastActivity,
-		})
+			ttl,
+		}
+		if wide {
+			row = append(row, scheduleColumns(&ws, now)...)
+		}
+		table.AddRow(row)
 	}
 
 	table.Render()
@@ -102,3 +124,28 @@ func runList(c *cobra.Command, args []string) error {
 
 	return nil
 }
+
+// scheduleColumns returns the NEXT SLEEP, NEXT WAKE and WINDOW cells of the wide output
+func scheduleColumns(ws *batchv1.Workspace, now time.Time) []string {
+	autoHibernation := ws.Spec.AutoHibernation
+	if autoHibernation == nil || !autoHibernation.Enabled {
+		return []string{"-", "-", "-"}
+	}
+
+	transitions, err := validation.UpcomingTransitions(autoHibernation.Schedule, wakeScheduleOf(autoHibernation), now, transitionSearchDepth)
+	if err != nil {
+		return []string{"invalid", "invalid", "invalid"}
+	}
+
+	nextSleep, nextWake := "-", "manual"
+	if t, ok := nextTransition(transitions, validation.TransitionSleep); ok {
+		nextSleep = printer.FormatInstant(t)
+	}
+	if t, ok := nextTransition(transitions, validation.TransitionWake); ok {
+		nextWake = printer.FormatInstant(t)
+	} else if autoHibernation.WakeSchedule != nil {
+		nextWake = "-"
+	}
+
+	return []string{nextSleep, nextWake, scheduleState(autoHibernation, now)}
+}
@@ -0,0 +1,136 @@
+package workspace
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"os/signal"
+	"sort"
+	"time"
+
+	"github.com/spf13/cobra"
+	"k8s.io/client-go/kubernetes"
+
+	"github.com/forkspacer/cli/cmd"
+	"github.com/forkspacer/cli/pkg/logs"
+	moduleService "github.com/forkspacer/cli/pkg/module"
+	"github.com/forkspacer/cli/pkg/styles"
+	workspaceService "github.com/forkspacer/cli/pkg/workspace"
+)
+
+var (
+	logsFollow    bool
+	logsSince     time.Duration
+	logsTail      int64
+	logsContainer string
+)
+
+var logsCmd = &cobra.Command{
+	Use:   "logs [name]",
+	Short: "Stream the logs of all modules in a workspace",
+	Long: `Stream the logs of every module in a workspace at once.
+
+The pods of each module's Helm release are found through the
+app.kubernetes.io/instance label. Every line is prefixed with its module and
+pod in a distinct color. Hibernated modules and modules without a Helm
+release are skipped.
+
+Examples:
+  # Print the logs of all modules
+  forkspacer workspace logs dev-env
+
+  # Follow new lines only
+  forkspacer workspace logs dev-env -f --tail 0
+
+  # Logs of the last 10 minutes
+  forkspacer workspace logs dev-env --since 10m`,
+	Args:              cobra.ExactArgs(1),
+	ValidArgsFunction: workspaceNameCompletion,
+	RunE:              runLogs,
+}
+
+func init() {
+	logsCmd.Flags().BoolVarP(&logsFollow, "follow", "f", false,
+		"Keep streaming new log lines")
+	logsCmd.Flags().DurationVar(&logsSince, "since", 0,
+		"Only show logs newer than a relative duration like 5m or 2h")
+	logsCmd.Flags().Int64Var(&logsTail, "tail", -1,
+		"Number of recent lines to show per container (-1 for all)")
+	logsCmd.Flags().StringVarP(&logsContainer, "container", "c", "",
+		"Only show logs of containers with this name")
+}
+
+func runLogs(c *cobra.Command, args []string) error {
+	name := args[0]
+	namespace := cmd.GetNamespace()
+
+	ctx, stop := signal.NotifyContext(context.Background(), os.Interrupt)
+	defer stop()
+
+	service, err := workspaceService.NewService()
+	if err != nil {
+		return fmt.Errorf("failed to connect to cluster: %w", err)
+	}
+	modService, err := moduleService.NewService()
+	if err != nil {
+		return fmt.Errorf("failed to connect to cluster: %w", err)
+	}
+
+	workspace, err := service.Get(ctx, name, namespace)
+	if err != nil {
+		return err
+	}
+	if err := workspaceService.CheckLocal(workspace); err != nil {
+		return err
+	}
+
+	modules, err := modService.ListByWorkspace(ctx, workspace.Name, workspace.Namespace)
+	if err != nil {
+		return fmt.Errorf("failed to list modules: %w", err)
+	}
+	sort.Slice(modules, func(i, j int) bool {
+		return modules[i].Name < modules[j].Name
+	})
+
+	var sources []logs.Source
+	for i := range modules {
+		mod := &modules[i]
+		if mod.Spec.Hibernated {
+			if cmd.IsVerbose() {
+				fmt.Println(styles.MutedStyle.Render(fmt.Sprintf("Skipping hibernated module %s", mod.Name)))
+			}
+			continue
+		}
+		if _, _, ok := moduleService.ReleaseName(mod); !ok {
+			if cmd.IsVerbose() {
+				fmt.Println(styles.MutedStyle.Render(fmt.Sprintf("Skipping module %s without a Helm release", mod.Name)))
+			}
+			continue
+		}
+
+		pods, err := modService.Pods(ctx, mod)
+		if err != nil {
+			return fmt.Errorf("failed to list pods of module %s: %w", mod.Name, err)
+		}
+		sources = append(sources, logs.Sources(pods, logsContainer, mod.Name)...)
+	}
+
+	if len(sources) == 0 {
+		if workspace.Spec.Hibernated {
+			fmt.Println(styles.Info(fmt.Sprintf("Workspace %s is hibernated and has no pods", name)))
+			return nil
+		}
+		return fmt.Errorf("no pods found for the modules of workspace %s", name)
+	}
+
+	clientset, err := kubernetes.NewForConfig(modService.RESTConfig())
+	if err != nil {
+		return fmt.Errorf("failed to connect to cluster: %w", err)
+	}
+
+	return logs.Stream(ctx, clientset, sources, logs.Options{
+		Follow: logsFollow,
+		Since:  logsSince,
+		Tail:   logsTail,
+	}, os.Stdout)
+}
@@ -0,0 +1,180 @@
+package workspace
+
+import (
+	"fmt"
+	"os"
+	"time"
+
+	batchv1 "github.com/forkspacer/forkspacer/api/v1"
+
+	"github.com/forkspacer/cli/pkg/printer"
+	"github.com/forkspacer/cli/pkg/styles"
+	"github.com/forkspacer/cli/pkg/validation"
+)
+
+// defaultUpcomingTransitions is how many scheduled transitions are shown by default
+const defaultUpcomingTransitions = 4
+
+// transitionSearchDepth is how many transitions are scanned when looking for
+// the next one of a kind, enough unless one schedule fires far more often
+const transitionSearchDepth = 16
+
+// timezoneLabel names the zone the schedules are evaluated in, noting a
+// wake schedule that uses a different zone
+func timezoneLabel(autoHibernation *batchv1.WorkspaceAutoHibernation) string {
+	label := validation.ScheduleTimezone(autoHibernation.Schedule)
+	if zone, _ := validation.SplitScheduleTimezone(autoHibernation.Schedule); zone == "" {
+		label += " (operator default)"
+	}
+
+	if wake := wakeScheduleOf(autoHibernation); wake != "" {
+		if wakeZone := validation.ScheduleTimezone(wake); wakeZone != validation.ScheduleTimezone(autoHibernation.Schedule) {
+			label += ", wake in " + wakeZone
+		}
+	}
+	return label
+}
+
+// describeSchedule returns the English description of a schedule, falling
+// back to the schedule itself
+func describeSchedule(schedule string) string {
+	description, err := validation.DescribeSchedule(schedule)
+	if err != nil {
+		return schedule
+	}
+	return description
+}
+
+// describedSchedule renders a schedule followed by its English description
+func describedSchedule(schedule string) string {
+	description, err := validation.DescribeSchedule(schedule)
+	if err != nil {
+		return styles.Value(schedule)
+	}
+	return styles.Value(schedule) + "  " + styles.MutedStyle.Render("("+description+")")
+}
+
+// wakeScheduleOf returns the wake schedule of an auto-hibernation config, or "" if none is set
+func wakeScheduleOf(autoHibernation *batchv1.WorkspaceAutoHibernation) string {
+	if autoHibernation.WakeSchedule == nil {
+		return ""
+	}
+	return *autoHibernation.WakeSchedule
+}
+
+// nextTransition returns the first transition of the given kind
+func nextTransition(transitions []validation.Transition, kind validation.TransitionKind) (time.Time, bool) {
+	for _, t := range transitions {
+		if t.Kind == kind {
+			return t.Time, true
+		}
+	}
+	return time.Time{}, false
+}
+
+// scheduleState reports whether the schedule currently leaves the workspace
+// inside its awake window: "awake", "asleep", "unknown" or "invalid"
+func scheduleState(autoHibernation *batchv1.WorkspaceAutoHibernation, now time.Time) string {
+	awake, known, err := validation.IsAwakeAt(autoHibernation.Schedule, wakeScheduleOf(autoHibernation), now)
+	switch {
+	case err != nil:
+		return "invalid"
+	case !known:
+		return "unknown"
+	case awake:
+		return "awake"
+	default:
+		return "asleep"
+	}
+}
+
+// scheduleWindow describes the current awake window and when it changes
+func scheduleWindow(autoHibernation *batchv1.WorkspaceAutoHibernation, now time.Time) string {
+	transitions, err := validation.UpcomingTransitions(autoHibernation.Schedule, wakeScheduleOf(autoHibernation), now, transitionSearchDepth)
+	if err != nil {
+		return "invalid schedule"
+	}
+
+	switch scheduleState(autoHibernation, now) {
+	case "awake":
+		if next, ok := nextTransition(transitions, validation.TransitionSleep); ok {
+			return "awake until " + printer.FormatInstant(next)
+		}
+		return "awake"
+	case "asleep":
+		if next, ok := nextTransition(transitions, validation.TransitionWake); ok {
+			return "asleep until " + printer.FormatInstant(next)
+		}
+		return "asleep (manual wake only)"
+	case "unknown":
+		return "unknown (no recent transition)"
+	default:
+		return "invalid schedule"
+	}
+}
+
+// printUpcomingTransitions prints the next n scheduled transitions followed
+// by the current awake window
+func printUpcomingTransitions(autoHibernation *batchv1.WorkspaceAutoHibernation, n int, indent string) {
+	now := time.Now()
+
+	wakeSchedule := wakeScheduleOf(autoHibernation)
+	transitions, err := validation.UpcomingTransitions(autoHibernation.Schedule, wakeSchedule, now, n)
+	if err != nil {
+		fmt.Printf("%s%s  %s\n", indent, styles.Key("Upcoming:"), styles.ErrorStyle.Render(err.Error()))
+		return
+	}
+
+	fmt.Printf("%s%s\n", indent, styles.Key("Upcoming (local time):"))
+	for _, t := range transitions {
+		label, schedule := "Sleep", autoHibernation.Schedule
+		if t.Kind == validation.TransitionWake {
+			label, schedule = "Wake ", wakeSchedule
+		}
+		fmt.Printf("%s  %s %s  %s\n", indent, styles.SymbolBullet, label,
+			styles.Value(printer.FormatScheduleInstant(t.Time, validation.ScheduleTimezone(schedule))))
+	}
+	fmt.Printf("%s%s  %s\n", indent, styles.Key("Window:"), styles.Value(scheduleWindow(autoHibernation, now)))
+}
+
+// checkSchedulePair analyzes a hibernation/wake schedule pair. Errors reject
+// the pair unless allow is set; warnings, and errors that were allowed, are
+// printed to stderr so they never mix with json/yaml output.
+func checkSchedulePair(sleepSchedule, wakeSchedule string, allow bool) (*validation.ScheduleAnalysis, error) {
+	analysis, err := validation.AnalyzeSchedulePair(sleepSchedule, wakeSchedule, time.Now())
+	if err != nil {
+		return nil, err
+	}
+
+	if analysis.HasErrors() && !allow {
+		return nil, formatScheduleIssues(sleepSchedule, wakeSchedule, analysis)
+	}
+
+	for _, issue := range analysis.Issues {
+		fmt.Fprintln(os.Stderr, styles.Warning("Schedule: "+issue.Message))
+	}
+	return analysis, nil
+}
+
+// awakeSummary describes how long the schedules keep the workspace awake
+func awakeSummary(analysis *validation.ScheduleAnalysis) string {
+	if analysis.ManualWake {
+		return "woken manually only"
+	}
+	return fmt.Sprintf("awake about %.0f h/week (%.0f%%)", analysis.AwakeHours, analysis.AwakeHours/168*100)
+}
+
+func formatScheduleIssues(sleepSchedule, wakeSchedule string, analysis *validation.ScheduleAnalysis) error {
+	msg := fmt.Sprintf("\n%s\n\n", styles.Error("Contradictory hibernation schedules"))
+	msg += fmt.Sprintf("  %s  %s\n", styles.Key("Sleep:"), describedSchedule(sleepSchedule))
+	if wakeSchedule != "" {
+		msg += fmt.Sprintf("  %s   %s\n", styles.Key("Wake:"), describedSchedule(wakeSchedule))
+	}
+	msg += fmt.Sprintf("\n  %s\n", styles.Key("Issues:"))
+	for _, issue := range analysis.Issues {
+		msg += fmt.Sprintf("    %s %s (%s)\n", styles.SymbolBullet, issue.Message, issue.Severity)
+	}
+	msg += fmt.Sprintf("\n  Pass %s to use these schedules anyway.\n", styles.Code("--allow"))
+
+	return fmt.Errorf("%s", msg)
+}
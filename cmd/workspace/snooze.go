@@ -0,0 +1,135 @@
+package workspace
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	batchv1 "github.com/forkspacer/forkspacer/api/v1"
+	"github.com/spf13/cobra"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	"github.com/forkspacer/cli/cmd"
+	"github.com/forkspacer/cli/pkg/printer"
+	"github.com/forkspacer/cli/pkg/styles"
+	workspaceService "github.com/forkspacer/cli/pkg/workspace"
+)
+
+var (
+	snoozeUntil string
+)
+
+var snoozeCmd = &cobra.Command{
+	Use:   "snooze [name]",
+	Short: "Pause auto-hibernation until a given time",
+	Long: `Pause a workspace's auto-hibernation schedule until a given time.
+
+The current schedule is saved on the workspace and auto-hibernation is
+disabled. It is restored by 'forkspacer workspace unsnooze', or by
+'forkspacer workspace expire' once the snooze has ended.
+
+--until accepts a clock time (22:00, today or tomorrow if already past),
+a local date and time (2025-10-20 09:00) or an RFC 3339 timestamp.
+
+Examples:
+  # Keep the workspace awake past the 6 PM schedule
+  forkspacer workspace snooze dev-env --until 22:00
+
+  # Snooze until Monday morning
+  forkspacer workspace snooze dev-env --until "2025-10-20 08:00"`,
+	Args:              cobra.ExactArgs(1),
+	ValidArgsFunction: workspaceNameCompletion,
+	RunE:              runSnooze,
+}
+
+func init() {
+	snoozeCmd.Flags().StringVar(&snoozeUntil, "until", "",
+		"When auto-hibernation resumes (e.g. 22:00, \"2025-10-20 08:00\")")
+	snoozeCmd.MarkFlagRequired("until")
+	cmd.AddDryRunFlag(snoozeCmd)
+}
+
+func runSnooze(c *cobra.Command, args []string) error {
+	name := args[0]
+	namespace := cmd.GetNamespace()
+
+	dryRun, err := cmd.GetDryRunStrategy(c)
+	if err != nil {
+		return err
+	}
+
+	until, err := parseUntil(snoozeUntil, time.Now())
+	if err != nil {
+		return err
+	}
+
+	ctx := context.Background()
+	service, err := workspaceService.NewService()
+	if err != nil {
+		return fmt.Errorf("failed to connect to cluster: %w", err)
+	}
+
+	if dryRun != cmd.DryRunNone {
+		var workspace *batchv1.Workspace
+		if dryRun == cmd.DryRunServer {
+			workspace, err = service.Snooze(ctx, name, namespace, until, client.DryRunAll)
+		} else {
+			workspace, err = service.Get(ctx, name, namespace)
+			if err == nil {
+				err = workspaceService.ApplySnooze(workspace, until)
+			}
+		}
+		if err != nil {
+			return err
+		}
+		return cmd.PrintDryRunResult(workspace, fmt.Sprintf("workspace/%s snoozed", name), dryRun)
+	}
+
+	sp := printer.NewSpinner("Snoozing auto-hibernation")
+	sp.Start()
+
+	if _, err := service.Snooze(ctx, name, namespace, until); err != nil {
+		sp.Error("Failed to snooze workspace")
+		return err
+	}
+
+	sp.Success(fmt.Sprintf("Auto-hibernation of %s snoozed until %s", name, until.Local().Format("2006-01-02 15:04")))
+
+	fmt.Println()
+	fmt.Println(styles.MutedStyle.Render("The saved schedule is restored by 'forkspacer workspace expire' after the snooze ends."))
+	fmt.Println()
+	fmt.Println(styles.SubtitleStyle.Render("Resume now:"))
+	fmt.Printf("  %s %s\n", styles.SymbolArrow, styles.Code(fmt.Sprintf("forkspacer workspace unsnooze %s", name)))
+	fmt.Println()
+
+	return nil
+}
+
+// parseUntil resolves a --until value relative to now. A bare clock time
+// refers to its next occurrence.
+func parseUntil(value string, now time.Time) (time.Time, error) {
+	if t, err := time.Parse(time.RFC3339, value); err == nil {
+		return checkFuture(t, now)
+	}
+
+	if t, err := time.ParseInLocation("2006-01-02 15:04", value, time.Local); err == nil {
+		return checkFuture(t, now)
+	}
+
+	if t, err := time.ParseInLocation("15:04", value, time.Local); err == nil {
+		next := time.Date(now.Year(), now.Month(), now.Day(), t.Hour(), t.Minute(), 0, 0, time.Local)
+		if !next.After(now) {
+			next = next.AddDate(0, 0, 1)
+		}
+		return next, nil
+	}
+
+	return time.Time{}, fmt.Errorf("invalid --until value %q (expected HH:MM, \"YYYY-MM-DD HH:MM\" or RFC 3339)", value)
+}
+
+func checkFuture(t, now time.Time) (time.Time, error) {
+	if !t.After(now) {
+		return time.Time{}, fmt.Errorf("--until %s is in the past", t.Local().Format("2006-01-02 15:04"))
+	}
+	return t, nil
+}
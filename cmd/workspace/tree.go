@@ -0,0 +1,256 @@
+package workspace
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	batchv1 "github.com/forkspacer/forkspacer/api/v1"
+	"github.com/spf13/cobra"
+
+	"github.com/forkspacer/cli/cmd"
+	"github.com/forkspacer/cli/pkg/printer"
+	"github.com/forkspacer/cli/pkg/styles"
+	workspaceService "github.com/forkspacer/cli/pkg/workspace"
+)
+
+var treeCmd = &cobra.Command{
+	Use:   "tree [name]",
+	Short: "Show the fork lineage of workspaces",
+	Long: `Show which workspaces were forked from which, across all namespaces.
+
+Without a name every fork tree in the cluster is shown. With a name only the
+tree containing that workspace is shown and the workspace is marked.
+
+Output formats: table (ASCII tree, default), json, yaml, and for docs
+dot (Graphviz) and mermaid.
+
+Examples:
+  # Show all fork trees
+  forkspacer workspace tree
+
+  # Show the lineage of a single workspace
+  forkspacer workspace tree staging -n production
+
+  # Render the fork graph with Graphviz
+  forkspacer workspace tree -o dot | dot -Tsvg > forks.svg
+
+  # Embed the fork graph in Markdown
+  forkspacer workspace tree -o mermaid`,
+	Args:              cobra.MaximumNArgs(1),
+	ValidArgsFunction: workspaceNameCompletion,
+	RunE:              runTree,
+}
+
+func runTree(c *cobra.Command, args []string) error {
+	output := cmd.GetOutput()
+	switch output {
+	case "table", "wide", "json", "yaml", "dot", "mermaid":
+	default:
+		return fmt.Errorf("unsupported output format %q (expected table, json, yaml, dot or mermaid)", output)
+	}
+
+	ctx := context.Background()
+	service, err := workspaceService.NewService()
+	if err != nil {
+		return fmt.Errorf("failed to connect to cluster: %w", err)
+	}
+
+	// Forks may cross namespaces, so the graph is always built from all of them
+	workspaces, err := service.List(ctx, "")
+	if err != nil {
+		return err
+	}
+
+	roots := workspaceService.BuildLineage(workspaces.Items)
+
+	var selected *workspaceService.LineageNode
+	if len(args) == 1 {
+		namespace := cmd.GetNamespace()
+		selected = workspaceService.FindLineageNode(roots, namespace, args[0])
+		if selected == nil {
+			return fmt.Errorf("workspace %s/%s not found", namespace, args[0])
+		}
+		roots = []*workspaceService.LineageNode{treeRootOf(roots, selected)}
+	}
+
+	switch output {
+	case "json", "yaml":
+		return printer.PrintObject(roots, output)
+	case "dot":
+		fmt.Print(renderDot(roots))
+		return nil
+	case "mermaid":
+		fmt.Print(renderMermaid(roots))
+		return nil
+	}
+
+	if len(roots) == 0 {
+		fmt.Println()
+		fmt.Println(styles.MutedStyle.Render("No workspaces found"))
+		fmt.Println()
+		return nil
+	}
+
+	fmt.Println()
+	for _, root := range roots {
+		printTreeNode(root, "", "", selected)
+	}
+	fmt.Println()
+
+	return nil
+}
+
+// treeRootOf returns the root of the tree that contains node
+func treeRootOf(roots []*workspaceService.LineageNode, node *workspaceService.LineageNode) *workspaceService.LineageNode {
+	for _, root := range roots {
+		found := false
+		root.Walk(func(n *workspaceService.LineageNode, _ int) {
+			if n == node {
+				found = true
+			}
+		})
+		if found {
+			return root
+		}
+	}
+	return node
+}
+
+// printTreeNode prints a node and its children with box-drawing connectors
+func printTreeNode(node *workspaceService.LineageNode, prefix, childPrefix string, selected *workspaceService.LineageNode) {
+	line := prefix + styles.Value(node.Name) + " " + styles.MutedStyle.Render("("+node.Namespace+")")
+	if badges := treeBadges(node); badges != "" {
+		line += "  " + badges
+	}
+	if node == selected {
+		line += "  " + styles.InfoStyle.Render("◀")
+	}
+	fmt.Println(line)
+
+	for i, child := range node.Children {
+		if i == len(node.Children)-1 {
+			printTreeNode(child, childPrefix+"└── ", childPrefix+"    ", selected)
+		} else {
+			printTreeNode(child, childPrefix+"├── ", childPrefix+"│   ", selected)
+		}
+	}
+}
+
+// treeBadges renders the phase and hibernation state of a node
+func treeBadges(node *workspaceService.LineageNode) string {
+	var badges []string
+
+	phase := node.Phase
+	if phase == "" {
+		phase = "unknown"
+	}
+	switch phase {
+	case string(batchv1.WorkspacePhaseReady):
+		badges = append(badges, styles.SuccessStyle.Render("["+phase+"]"))
+	case string(batchv1.WorkspacePhaseFailed):
+		badges = append(badges, styles.ErrorStyle.Render("["+phase+"]"))
+	default:
+		badges = append(badges, styles.WarningStyle.Render("["+phase+"]"))
+	}
+
+	if node.Hibernated && phase != string(batchv1.WorkspacePhaseHibernated) {
+		badges = append(badges, styles.MutedStyle.Render("[hibernated]"))
+	}
+	if node.MissingParent != "" {
+		badges = append(badges, styles.ErrorStyle.Render("[parent "+node.MissingParent+" missing]"))
+	}
+
+	return strings.Join(badges, " ")
+}
+
+// renderDot renders the fork graph in Graphviz DOT syntax
+func renderDot(roots []*workspaceService.LineageNode) string {
+	var b strings.Builder
+	b.WriteString("digraph forks {\n")
+	b.WriteString("  rankdir=LR;\n")
+	b.WriteString("  node [shape=box, style=rounded];\n")
+
+	var edges []string
+	for _, root := range roots {
+		root.Walk(func(node *workspaceService.LineageNode, _ int) {
+			attrs := fmt.Sprintf("label=%q", node.Name+"\n"+node.Namespace+" · "+nodeState(node))
+			if node.Hibernated {
+				attrs += ", style=\"rounded,dashed\""
+			}
+			fmt.Fprintf(&b, "  %q [%s];\n", node.ID(), attrs)
+
+			if node.MissingParent != "" {
+				fmt.Fprintf(&b, "  %q [label=%q, style=dotted];\n", node.MissingParent, node.MissingParent+"\n(missing)")
+				edges = append(edges, fmt.Sprintf("  %q -> %q [style=dotted];\n", node.MissingParent, node.ID()))
+			}
+			for _, child := range node.Children {
+				edges = append(edges, fmt.Sprintf("  %q -> %q;\n", node.ID(), child.ID()))
+			}
+		})
+	}
+
+	for _, edge := range edges {
+		b.WriteString(edge)
+	}
+	b.WriteString("}\n")
+	return b.String()
+}
+
+// renderMermaid renders the fork graph as a Mermaid flowchart
+func renderMermaid(roots []*workspaceService.LineageNode) string {
+	var b strings.Builder
+	b.WriteString("flowchart LR\n")
+
+	ids := make(map[string]string)
+	idOf := func(key string) string {
+		if id, ok := ids[key]; ok {
+			return id
+		}
+		id := fmt.Sprintf("ws%d", len(ids))
+		ids[key] = id
+		return id
+	}
+
+	var edges, hibernated []string
+	for _, root := range roots {
+		root.Walk(func(node *workspaceService.LineageNode, _ int) {
+			id := idOf(node.ID())
+			fmt.Fprintf(&b, "  %s[\"%s<br/>%s · %s\"]\n", id, mermaidText(node.Name), mermaidText(node.Namespace), nodeState(node))
+			if node.Hibernated {
+				hibernated = append(hibernated, id)
+			}
+
+			if node.MissingParent != "" {
+				parent := idOf(node.MissingParent)
+				fmt.Fprintf(&b, "  %s[\"%s<br/>(missing)\"]\n", parent, mermaidText(node.MissingParent))
+				edges = append(edges, fmt.Sprintf("  %s -.-> %s\n", parent, id))
+			}
+			for _, child := range node.Children {
+				edges = append(edges, fmt.Sprintf("  %s --> %s\n", id, idOf(child.ID())))
+			}
+		})
+	}
+
+	for _, edge := range edges {
+		b.WriteString(edge)
+	}
+	if len(hibernated) > 0 {
+		b.WriteString("  classDef hibernated stroke-dasharray: 5 5\n")
+		fmt.Fprintf(&b, "  class %s hibernated\n", strings.Join(hibernated, ","))
+	}
+	return b.String()
+}
+
+// nodeState is the plain-text phase of a node for graph labels
+func nodeState(node *workspaceService.LineageNode) string {
+	if node.Phase == "" {
+		return "unknown"
+	}
+	return node.Phase
+}
+
+// mermaidText escapes characters that end a quoted Mermaid label
+func mermaidText(s string) string {
+	return strings.ReplaceAll(s, `"`, "#quot;")
+}
@@ -0,0 +1,77 @@
+package workspace
+
+import (
+	"context"
+	"fmt"
+
+	batchv1 "github.com/forkspacer/forkspacer/api/v1"
+	"github.com/spf13/cobra"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	"github.com/forkspacer/cli/cmd"
+	"github.com/forkspacer/cli/pkg/printer"
+	workspaceService "github.com/forkspacer/cli/pkg/workspace"
+)
+
+var unsnoozeCmd = &cobra.Command{
+	Use:   "unsnooze [name]",
+	Short: "Restore a snoozed auto-hibernation schedule",
+	Long: `Restore the auto-hibernation schedule saved by 'forkspacer workspace snooze'
+before the snooze ends.
+
+Examples:
+  # Resume the regular schedule
+  forkspacer workspace unsnooze dev-env`,
+	Args:              cobra.ExactArgs(1),
+	ValidArgsFunction: workspaceNameCompletion,
+	RunE:              runUnsnooze,
+}
+
+func init() {
+	cmd.AddDryRunFlag(unsnoozeCmd)
+}
+
+func runUnsnooze(c *cobra.Command, args []string) error {
+	name := args[0]
+	namespace := cmd.GetNamespace()
+
+	dryRun, err := cmd.GetDryRunStrategy(c)
+	if err != nil {
+		return err
+	}
+
+	ctx := context.Background()
+	service, err := workspaceService.NewService()
+	if err != nil {
+		return fmt.Errorf("failed to connect to cluster: %w", err)
+	}
+
+	if dryRun != cmd.DryRunNone {
+		var workspace *batchv1.Workspace
+		if dryRun == cmd.DryRunServer {
+			workspace, err = service.Unsnooze(ctx, name, namespace, client.DryRunAll)
+		} else {
+			workspace, err = service.Get(ctx, name, namespace)
+			if err == nil {
+				err = workspaceService.ApplyUnsnooze(workspace)
+			}
+		}
+		if err != nil {
+			return err
+		}
+		return cmd.PrintDryRunResult(workspace, fmt.Sprintf("workspace/%s unsnoozed", name), dryRun)
+	}
+
+	sp := printer.NewSpinner("Restoring auto-hibernation schedule")
+	sp.Start()
+
+	if _, err := service.Unsnooze(ctx, name, namespace); err != nil {
+		sp.Error("Failed to unsnooze workspace")
+		return err
+	}
+
+	sp.Success(fmt.Sprintf("Auto-hibernation of %s restored", name))
+	fmt.Println()
+
+	return nil
+}
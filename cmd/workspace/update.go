@@ -0,0 +1,261 @@
+package workspace
+
+import (
+	"context"
+	"fmt"
+
+	batchv1 "github.com/forkspacer/forkspacer/api/v1"
+	"github.com/spf13/cobra"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	"github.com/forkspacer/cli/cmd"
+	"github.com/forkspacer/cli/pkg/printer"
+	"github.com/forkspacer/cli/pkg/styles"
+	"github.com/forkspacer/cli/pkg/validation"
+	workspaceService "github.com/forkspacer/cli/pkg/workspace"
+)
+
+var (
+	updateHibernationSched string
+	updateWakeSched        string
+	updateNoWakeSched      bool
+	updateTimezone         string
+	updateDisable          bool
+	updateAllow            bool
+)
+
+var updateCmd = &cobra.Command{
+	Use:   "update [name]",
+	Short: "Update the auto-hibernation settings of a workspace",
+	Long: `Update the auto-hibernation schedules and timezone of an existing workspace.
+
+Only the given settings change. A new schedule without its own timezone keeps
+the zone the workspace already uses, and --timezone alone moves the existing
+schedules to another zone. Changing a schedule or the timezone enables
+auto-hibernation if it was disabled. While a workspace is snoozed, the saved
+schedule is updated and takes effect when the snooze ends.
+
+Examples:
+  # Move the evening hibernation to 7 PM
+  forkspacer workspace update dev-env --hibernation-schedule "0 19 * * 1-5"
+
+  # Evaluate the existing schedules in New York time
+  forkspacer workspace update dev-env --timezone America/New_York
+
+  # Only wake the workspace manually from now on
+  forkspacer workspace update dev-env --no-wake-schedule
+
+  # Turn auto-hibernation off, keeping the schedules for later
+  forkspacer workspace update dev-env --disable-hibernation`,
+	Args:              cobra.ExactArgs(1),
+	ValidArgsFunction: workspaceNameCompletion,
+	RunE:              runUpdate,
+}
+
+func init() {
+	updateCmd.Flags().StringVar(&updateHibernationSched, "hibernation-schedule", "",
+		"Hibernation cron schedule (e.g., '0 18 * * *' for 6 PM daily)")
+	updateCmd.Flags().StringVar(&updateWakeSched, "wake-schedule", "",
+		"Wake cron schedule (e.g., '0 8 * * *' for 8 AM daily)")
+	updateCmd.Flags().BoolVar(&updateNoWakeSched, "no-wake-schedule", false,
+		"Remove the wake schedule so the workspace is only woken manually")
+	updateCmd.Flags().StringVar(&updateTimezone, "timezone", "",
+		"IANA timezone the schedules are evaluated in (e.g., Europe/Berlin)")
+	updateCmd.Flags().BoolVar(&updateDisable, "disable-hibernation", false,
+		"Disable auto-hibernation")
+	updateCmd.Flags().BoolVar(&updateAllow, "allow", false,
+		"Accept contradictory hibernation/wake schedules with a warning")
+	cmd.AddDryRunFlag(updateCmd)
+}
+
+func runUpdate(c *cobra.Command, args []string) error {
+	name := args[0]
+	namespace := cmd.GetNamespace()
+
+	dryRun, err := cmd.GetDryRunStrategy(c)
+	if err != nil {
+		return err
+	}
+
+	if err := validateUpdateFlags(c); err != nil {
+		return err
+	}
+
+	ctx := context.Background()
+	service, err := workspaceService.NewService()
+	if err != nil {
+		return fmt.Errorf("failed to connect to cluster: %w", err)
+	}
+
+	workspace, err := service.Get(ctx, name, namespace)
+	if err != nil {
+		return err
+	}
+
+	current := workspace.Spec.AutoHibernation
+	saved, err := workspaceService.SnoozedAutoHibernation(workspace)
+	if err != nil {
+		return err
+	}
+	if saved != nil {
+		current = saved
+	}
+
+	autoHibernation, err := buildUpdatedAutoHibernation(c, current)
+	if err != nil {
+		return err
+	}
+
+	if dryRun != cmd.DryRunNone {
+		if dryRun == cmd.DryRunServer {
+			workspace, err = service.SetAutoHibernation(ctx, name, namespace, autoHibernation, client.DryRunAll)
+		} else {
+			err = workspaceService.ApplyAutoHibernation(workspace, autoHibernation)
+		}
+		if err != nil {
+			return err
+		}
+		return cmd.PrintDryRunResult(workspace, fmt.Sprintf("workspace/%s updated", name), dryRun)
+	}
+
+	sp := printer.NewSpinner("Updating auto-hibernation")
+	sp.Start()
+
+	if _, err := service.SetAutoHibernation(ctx, name, namespace, autoHibernation); err != nil {
+		sp.Error("Failed to update workspace")
+		return err
+	}
+	sp.Success(fmt.Sprintf("Workspace %s updated", name))
+
+	fmt.Println()
+	if !autoHibernation.Enabled {
+		fmt.Printf("%s  %s\n", styles.Key("Hibernation:"), styles.Value("disabled"))
+	} else {
+		fmt.Printf("%s  %s\n", styles.Key("Hibernation:"), styles.Value("enabled"))
+		fmt.Printf("  %s  %s\n", styles.Key("Sleep:"), describedSchedule(autoHibernation.Schedule))
+		if autoHibernation.WakeSchedule != nil {
+			fmt.Printf("  %s  %s\n", styles.Key("Wake:"), describedSchedule(*autoHibernation.WakeSchedule))
+		}
+		fmt.Printf("  %s  %s\n", styles.Key("Timezone:"), styles.Value(timezoneLabel(autoHibernation)))
+		printUpcomingTransitions(autoHibernation, defaultUpcomingTransitions, "  ")
+	}
+
+	if saved != nil && autoHibernation.Enabled {
+		fmt.Println()
+		fmt.Println(styles.MutedStyle.Render("The workspace is snoozed; the new schedule applies when the snooze ends."))
+	}
+	fmt.Println()
+
+	return nil
+}
+
+// validateUpdateFlags rejects empty and contradictory flag combinations
+func validateUpdateFlags(c *cobra.Command) error {
+	changed := false
+	for _, flag := range []string{"hibernation-schedule", "wake-schedule", "no-wake-schedule", "timezone", "disable-hibernation"} {
+		if c.Flags().Changed(flag) {
+			changed = true
+		}
+	}
+	if !changed {
+		return fmt.Errorf("nothing to update: specify --hibernation-schedule, --wake-schedule, --no-wake-schedule, --timezone or --disable-hibernation")
+	}
+
+	if updateDisable && (updateHibernationSched != "" || updateWakeSched != "" || updateNoWakeSched || updateTimezone != "") {
+		return fmt.Errorf("--disable-hibernation cannot be combined with schedule or timezone flags")
+	}
+	if updateNoWakeSched && updateWakeSched != "" {
+		return fmt.Errorf("--no-wake-schedule cannot be combined with --wake-schedule")
+	}
+	if c.Flags().Changed("timezone") {
+		if _, err := validation.ValidateTimezone(updateTimezone); err != nil {
+			return fmt.Errorf("invalid --timezone: %w", err)
+		}
+	}
+
+	return nil
+}
+
+// buildUpdatedAutoHibernation merges the update flags into the current
+// auto-hibernation settings, which may be nil
+func buildUpdatedAutoHibernation(c *cobra.Command, current *batchv1.WorkspaceAutoHibernation) (*batchv1.WorkspaceAutoHibernation, error) {
+	if updateDisable {
+		if current == nil {
+			return nil, fmt.Errorf("auto-hibernation is not configured on this workspace")
+		}
+		disabled := current.DeepCopy()
+		disabled.Enabled = false
+		return disabled, nil
+	}
+
+	var sleep, wake string
+	if current != nil {
+		sleep = current.Schedule
+		wake = wakeScheduleOf(current)
+	}
+
+	// New schedules without their own prefix inherit the current zone
+	zone, _ := validation.SplitScheduleTimezone(sleep)
+	if c.Flags().Changed("timezone") {
+		zone = updateTimezone
+	}
+
+	encode := func(schedule string) (string, error) {
+		if zone == "" {
+			return schedule, nil
+		}
+		if scheduleZone, _ := validation.SplitScheduleTimezone(schedule); scheduleZone != "" && !c.Flags().Changed("timezone") {
+			return schedule, nil
+		}
+		return validation.WithTimezone(schedule, zone)
+	}
+
+	var err error
+	if updateHibernationSched != "" {
+		if sleep, err = encode(updateHibernationSched); err != nil {
+			return nil, fmt.Errorf("invalid --timezone: %w", err)
+		}
+	} else if sleep != "" && c.Flags().Changed("timezone") {
+		if sleep, err = validation.ReplaceTimezone(sleep, zone); err != nil {
+			return nil, err
+		}
+	}
+
+	switch {
+	case updateNoWakeSched:
+		wake = ""
+	case updateWakeSched != "":
+		if wake, err = encode(updateWakeSched); err != nil {
+			return nil, fmt.Errorf("invalid --timezone: %w", err)
+		}
+	case wake != "" && c.Flags().Changed("timezone"):
+		if wake, err = validation.ReplaceTimezone(wake, zone); err != nil {
+			return nil, err
+		}
+	}
+
+	if sleep == "" {
+		return nil, fmt.Errorf("the workspace has no hibernation schedule, specify --hibernation-schedule")
+	}
+
+	if err := validation.ValidateCronSchedule(sleep); err != nil {
+		return nil, formatCronError(sleep, err)
+	}
+	autoHibernation := &batchv1.WorkspaceAutoHibernation{
+		Enabled:  true,
+		Schedule: sleep,
+	}
+
+	if wake != "" {
+		if err := validation.ValidateCronSchedule(wake); err != nil {
+			return nil, formatCronError(wake, err)
+		}
+		autoHibernation.WakeSchedule = &wake
+	}
+
+	if _, err := checkSchedulePair(sleep, wake, updateAllow); err != nil {
+		return nil, err
+	}
+
+	return autoHibernation, nil
+}
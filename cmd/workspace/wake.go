@@ -3,6 +3,7 @@ package workspace
 import (
 	"context"
 	"fmt"
+	"time"
 
 	"github.com/spf13/cobra"
 
@@ -13,7 +14,7 @@ import (
 )
 
 var wakeCmd = &cobra.Command{
-	Use:   "wake [name]",
+	Use:   "wake [name...]",
 	Short: "Wake up a hibernated workspace",
 	Long: `Wake up a hibernated workspace to restore all modules.
 
@@ -22,27 +23,77 @@ This will:
   • Resume all services
   • Make the workspace active again
 
+With --for the wake is temporary: the end time is recorded on the workspace
+and 'forkspacer workspace expire' hibernates it again once that time passes.
+
 Examples:
   # Wake up a workspace
   forkspacer workspace wake dev-env
 
   # Wake workspace in specific namespace
-  forkspacer workspace wake staging -n production`,
-	Args:              cobra.ExactArgs(1),
-	ValidArgsFunction: workspaceNameCompletion,
+  forkspacer workspace wake staging -n production
+
+  # Wake for a two hour session outside the auto-hibernation schedule
+  forkspacer workspace wake dev-env --for 2h
+
+  # Wake every workspace in the namespace
+  forkspacer workspace wake --all -n staging
+
+  # Preview the updated workspace without waking it
+  forkspacer workspace wake dev-env --dry-run=client -o yaml`,
+	Args:              cobra.ArbitraryArgs,
+	ValidArgsFunction: workspaceNamesCompletion,
 	RunE:              runWake,
 }
 
+var (
+	wakeFor time.Duration
+)
+
+func init() {
+	wakeCmd.Flags().DurationVar(&wakeFor, "for", 0,
+		"Wake temporarily for the given duration (e.g. 90m, 2h)")
+	cmd.AddDryRunFlag(wakeCmd)
+	cmd.AddSelectionFlags(wakeCmd)
+}
+
 func runWake(c *cobra.Command, args []string) error {
-	name := args[0]
 	namespace := cmd.GetNamespace()
 
+	selection, err := cmd.GetSelection(c, args)
+	if err != nil {
+		return err
+	}
+
+	dryRun, err := cmd.GetDryRunStrategy(c)
+	if err != nil {
+		return err
+	}
+
+	var wakeUntil *time.Time
+	if c.Flags().Changed("for") {
+		if wakeFor <= 0 {
+			return fmt.Errorf("--for must be a positive duration")
+		}
+		until := time.Now().Add(wakeFor)
+		wakeUntil = &until
+	}
+
 	ctx := context.Background()
 	service, err := workspaceService.NewService()
 	if err != nil {
 		return fmt.Errorf("failed to connect to cluster: %w", err)
 	}
 
+	if !selection.IsSingle() {
+		return runSetHibernationBatch(ctx, service, selection, namespace, false, wakeUntil, dryRun)
+	}
+	name := selection.Names[0]
+
+	if dryRun != cmd.DryRunNone {
+		return runSetHibernationDryRun(ctx, service, name, namespace, false, wakeUntil, dryRun)
+	}
+
 	// Get workspace
 	sp := printer.NewSpinner("Fetching workspace")
 	sp.Start()
@@ -53,6 +104,22 @@ func runWake(c *cobra.Command, args []string) error {
 		return err
 	}
 
+	// An awake workspace still gets the --for deadline, otherwise
+	// 'workspace expire' would never hibernate it
+	if !workspace.Spec.Hibernated && wakeUntil != nil {
+		sp.Stop()
+		if _, err := setHibernation(ctx, service, name, namespace, false, wakeUntil); err != nil {
+			fmt.Println(styles.Error("Failed to set wake deadline"))
+			return err
+		}
+		fmt.Println()
+		fmt.Println(styles.Info(fmt.Sprintf("Workspace %s is already awake", name)))
+		fmt.Println(styles.MutedStyle.Render(fmt.Sprintf("The workspace will be hibernated after %s by 'forkspacer workspace expire'.",
+			wakeUntil.Local().Format("2006-01-02 15:04:05"))))
+		fmt.Println()
+		return nil
+	}
+
 	if !workspace.Spec.Hibernated {
 		sp.Stop()
 		fmt.Println()
@@ -67,7 +134,7 @@ func runWake(c *cobra.Command, args []string) error {
 	sp = printer.NewSpinner("Waking up workspace")
 	sp.Start()
 
-	_, err = service.SetHibernation(ctx, name, namespace, false)
+	_, err = setHibernation(ctx, service, name, namespace, false, wakeUntil)
 	if err != nil {
 		sp.Error("Failed to wake workspace")
 		return err
@@ -77,6 +144,10 @@ func runWake(c *cobra.Command, args []string) error {
 
 	fmt.Println()
 	fmt.Println(styles.MutedStyle.Render("All modules in this workspace will scale back to their original state."))
+	if wakeUntil != nil {
+		fmt.Println(styles.MutedStyle.Render(fmt.Sprintf("The workspace will be hibernated again after %s by 'forkspacer workspace expire'.",
+			wakeUntil.Local().Format("2006-01-02 15:04:05"))))
+	}
 	fmt.Println()
 	fmt.Println(styles.SubtitleStyle.Render("Check status:"))
 	fmt.Printf("  %s %s\n", styles.SymbolArrow, styles.Code(fmt.Sprintf("forkspacer workspace get %s", name)))
@@ -2,10 +2,14 @@ package workspace
 
 import (
 	"context"
+	"fmt"
+	"slices"
 
 	"github.com/forkspacer/cli/cmd"
+	"github.com/forkspacer/cli/pkg/batch"
 	workspaceService "github.com/forkspacer/cli/pkg/workspace"
 	"github.com/spf13/cobra"
+	"sigs.k8s.io/controller-runtime/pkg/client"
 )
 
 // WorkspaceCmd represents the workspace command
@@ -29,9 +33,21 @@ func init() {
 	WorkspaceCmd.AddCommand(createCmd)
 	WorkspaceCmd.AddCommand(listCmd)
 	WorkspaceCmd.AddCommand(getCmd)
+	WorkspaceCmd.AddCommand(describeCmd)
+	WorkspaceCmd.AddCommand(treeCmd)
+	WorkspaceCmd.AddCommand(logsCmd)
+	WorkspaceCmd.AddCommand(endpointsCmd)
+	WorkspaceCmd.AddCommand(kubeconfigCmd)
+	WorkspaceCmd.AddCommand(updateCmd)
 	WorkspaceCmd.AddCommand(deleteCmd)
 	WorkspaceCmd.AddCommand(hibernateCmd)
 	WorkspaceCmd.AddCommand(wakeCmd)
+	WorkspaceCmd.AddCommand(expireCmd)
+	WorkspaceCmd.AddCommand(snoozeCmd)
+	WorkspaceCmd.AddCommand(unsnoozeCmd)
+	WorkspaceCmd.AddCommand(idleCmd)
+	WorkspaceCmd.AddCommand(extendCmd)
+	WorkspaceCmd.AddCommand(gcCmd)
 }
 
 // workspaceNameCompletion provides dynamic completion for workspace names
@@ -41,6 +57,11 @@ func workspaceNameCompletion(cmd *cobra.Command, args []string, toComplete strin
 		return nil, cobra.ShellCompDirectiveNoFileComp
 	}
 
+	return workspaceNamesCompletion(cmd, args, toComplete)
+}
+
+// workspaceNamesCompletion completes workspace names for commands that accept several
+func workspaceNamesCompletion(cmd *cobra.Command, args []string, toComplete string) ([]string, cobra.ShellCompDirective) {
 	// Create workspace service
 	ctx := context.Background()
 	service, err := workspaceService.NewService()
@@ -57,11 +78,58 @@ func workspaceNameCompletion(cmd *cobra.Command, args []string, toComplete strin
 		return nil, cobra.ShellCompDirectiveError
 	}
 
-	// Extract workspace names
+	// Extract workspace names, skipping ones already given
 	var names []string
 	for _, ws := range workspaces.Items {
-		names = append(names, ws.Name)
+		if !slices.Contains(args, ws.Name) {
+			names = append(names, ws.Name)
+		}
 	}
 
 	return names, cobra.ShellCompDirectiveNoFileComp
 }
+
+// resolveWorkspaceTargets expands a selection into the workspaces it refers to
+func resolveWorkspaceTargets(
+	ctx context.Context,
+	service *workspaceService.Service,
+	selection *cmd.Selection,
+	namespace string,
+) ([]batch.Target, error) {
+	var targets []batch.Target
+
+	if len(selection.Names) > 0 {
+		for _, name := range selection.Names {
+			targets = append(targets, batch.Target{Name: name, Namespace: namespace})
+		}
+		return targets, nil
+	}
+
+	var opts []client.ListOption
+	if selection.Selector != nil {
+		opts = append(opts, client.MatchingLabelsSelector{Selector: selection.Selector})
+	}
+
+	workspaces, err := service.List(ctx, namespace, opts...)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list workspaces: %w", err)
+	}
+
+	for _, ws := range workspaces.Items {
+		targets = append(targets, batch.Target{Name: ws.Name, Namespace: ws.Namespace})
+	}
+
+	if len(targets) == 0 {
+		return nil, fmt.Errorf("no workspaces matched in namespace %s", namespace)
+	}
+
+	return targets, nil
+}
+
+// dryRunMessage appends the dry-run mode to a batch result message
+func dryRunMessage(message string, dryRun cmd.DryRunStrategy) string {
+	if dryRun == cmd.DryRunNone {
+		return message
+	}
+	return fmt.Sprintf("%s (%s dry run)", message, dryRun)
+}
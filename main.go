@@ -2,7 +2,11 @@ package main
 
 import (
 	"github.com/forkspacer/cli/cmd"
+	_ "github.com/forkspacer/cli/cmd/doctor"
 	_ "github.com/forkspacer/cli/cmd/module"
+	_ "github.com/forkspacer/cli/cmd/operator"
+	_ "github.com/forkspacer/cli/cmd/report"
+	_ "github.com/forkspacer/cli/cmd/schedule"
 	_ "github.com/forkspacer/cli/cmd/workspace"
 )
 
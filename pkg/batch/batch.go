@@ -0,0 +1,67 @@
+package batch
+
+import (
+	"context"
+	"sync"
+)
+
+// DefaultConcurrency is the number of operations run in parallel unless overridden
+const DefaultConcurrency = 5
+
+// Target identifies a namespaced object an operation is applied to
+type Target struct {
+	Name      string
+	Namespace string
+}
+
+// Result records the outcome of an operation on a single target
+type Result struct {
+	Target
+	Message string
+	Err     error
+}
+
+// Operation is applied to each target; the returned message describes what happened
+type Operation func(ctx context.Context, target Target) (string, error)
+
+// Run applies op to every target using at most workers concurrent goroutines.
+// Results are returned in the same order as targets.
+func Run(ctx context.Context, targets []Target, workers int, op Operation) []Result {
+	if workers < 1 {
+		workers = 1
+	}
+
+	results := make([]Result, len(targets))
+	indexes := make(chan int)
+
+	var wg sync.WaitGroup
+	for i := 0; i < workers && i < len(targets); i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			for idx := range indexes {
+				message, err := op(ctx, targets[idx])
+				results[idx] = Result{Target: targets[idx], Message: message, Err: err}
+			}
+		}()
+	}
+
+	for i := range targets {
+		indexes <- i
+	}
+	close(indexes)
+	wg.Wait()
+
+	return results
+}
+
+// Failed returns the number of results that carry an error
+func Failed(results []Result) int {
+	failed := 0
+	for _, r := range results {
+		if r.Err != nil {
+			failed++
+		}
+	}
+	return failed
+}
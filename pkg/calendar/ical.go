@@ -0,0 +1,163 @@
+package calendar
+
+import (
+	"fmt"
+	"io"
+	"strings"
+	"time"
+)
+
+// maxLineOctets is the longest content line allowed by RFC 5545 before folding
+const maxLineOctets = 75
+
+// Event is a single VEVENT, either one occurrence or a recurring series
+type Event struct {
+	UID         string
+	Summary     string
+	Description string
+	Start       time.Time
+	Duration    time.Duration
+
+	// RRule is the recurrence rule without the "RRULE:" prefix, empty for a
+	// single occurrence
+	RRule string
+
+	// TZID names the zone Start is expressed in, so recurrences follow its
+	// wall clock across DST changes. Empty means UTC. The calendar writes a
+	// VTIMEZONE for every zone its events use.
+	TZID string
+}
+
+// Calendar is an iCalendar (RFC 5545) VCALENDAR object
+type Calendar struct {
+	Name   string
+	Events []Event
+}
+
+// Write encodes the calendar with CRLF line endings and folded long lines
+func (c *Calendar) Write(w io.Writer) error {
+	var b strings.Builder
+	line := func(format string, args ...interface{}) {
+		writeFolded(&b, fmt.Sprintf(format, args...))
+	}
+
+	stamp := time.Now().UTC().Format("20060102T150405Z")
+
+	line("BEGIN:VCALENDAR")
+	line("VERSION:2.0")
+	line("PRODID:-//Forkspacer//Forkspacer CLI//EN")
+	line("CALSCALE:GREGORIAN")
+	if c.Name != "" {
+		line("X-WR-CALNAME:%s", escapeText(c.Name))
+	}
+
+	// Each TZID needs a VTIMEZONE covering its events, which start no
+	// earlier than the first event in that zone
+	var zones []string
+	earliest := map[string]time.Time{}
+	for _, e := range c.Events {
+		if e.TZID == "" {
+			continue
+		}
+		first, ok := earliest[e.TZID]
+		if !ok {
+			zones = append(zones, e.TZID)
+		}
+		if !ok || e.Start.Before(first) {
+			earliest[e.TZID] = e.Start
+		}
+	}
+	for _, tzid := range zones {
+		if err := writeTimezone(line, tzid, earliest[tzid]); err != nil {
+			return err
+		}
+	}
+
+	for _, e := range c.Events {
+		line("BEGIN:VEVENT")
+		line("UID:%s", e.UID)
+		line("DTSTAMP:%s", stamp)
+		if e.TZID != "" {
+			line("DTSTART;TZID=%s:%s", e.TZID, e.Start.Format("20060102T150405"))
+		} else {
+			line("DTSTART:%s", e.Start.UTC().Format("20060102T150405Z"))
+		}
+		line("DURATION:%s", formatDuration(e.Duration))
+		if e.RRule != "" {
+			line("RRULE:%s", e.RRule)
+		}
+		line("SUMMARY:%s", escapeText(e.Summary))
+		if e.Description != "" {
+			line("DESCRIPTION:%s", escapeText(e.Description))
+		}
+		line("TRANSP:TRANSPARENT")
+		line("END:VEVENT")
+	}
+
+	line("END:VCALENDAR")
+
+	_, err := io.WriteString(w, b.String())
+	return err
+}
+
+// writeFolded writes a content line, folding it into continuation lines
+// that start with a space once it exceeds 75 octets
+func writeFolded(b *strings.Builder, line string) {
+	limit := maxLineOctets
+	for len(line) > limit {
+		// Do not split a multi-byte UTF-8 sequence
+		cut := limit
+		for cut > 0 && line[cut]&0xC0 == 0x80 {
+			cut--
+		}
+		b.WriteString(line[:cut])
+		b.WriteString("\r\n ")
+		line = line[cut:]
+		limit = maxLineOctets - 1
+	}
+	b.WriteString(line)
+	b.WriteString("\r\n")
+}
+
+// escapeText escapes a TEXT property value
+func escapeText(s string) string {
+	return strings.NewReplacer(
+		`\`, `\\`,
+		";", `\;`,
+		",", `\,`,
+		"\n", `\n`,
+	).Replace(s)
+}
+
+// formatDuration renders a non-negative duration as an RFC 5545 DURATION
+func formatDuration(d time.Duration) string {
+	if d <= 0 {
+		return "PT0S"
+	}
+
+	days := int(d / (24 * time.Hour))
+	d -= time.Duration(days) * 24 * time.Hour
+	hours := int(d / time.Hour)
+	d -= time.Duration(hours) * time.Hour
+	minutes := int(d / time.Minute)
+	d -= time.Duration(minutes) * time.Minute
+	seconds := int(d / time.Second)
+
+	s := "P"
+	if days > 0 {
+		s += fmt.Sprintf("%dD", days)
+	}
+	if hours > 0 || minutes > 0 || seconds > 0 {
+		s += "T"
+		if hours > 0 {
+			s += fmt.Sprintf("%dH", hours)
+		}
+		if minutes > 0 {
+			s += fmt.Sprintf("%dM", minutes)
+		}
+		if seconds > 0 {
+			s += fmt.Sprintf("%dS", seconds)
+		}
+	}
+	return s
+}
@@ -0,0 +1,166 @@
+package calendar
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/robfig/cron/v3"
+
+	"github.com/forkspacer/cli/pkg/validation"
+)
+
+// starBit marks a field written as * or ? in robfig/cron's bit sets
+const starBit = 1 << 63
+
+// allMonths is the month bit set of a schedule that fires every month
+const allMonths = 0x1FFE
+
+// week is the period a weekly recurrence is derived from
+const week = 7 * 24 * time.Hour
+
+var weekdayCodes = [...]string{"SU", "MO", "TU", "WE", "TH", "FR", "SA"}
+
+// ScheduleEvents converts a hibernation/wake schedule pair into events for
+// the periods the workspace is hibernated. Pairs that fire at fixed times on
+// fixed weekdays in the same zone become weekly recurring events starting a
+// week before from; other pairs, such as steps or days of the month, are
+// expanded into single occurrences that start in [from, to). Without a wake
+// schedule each hibernation is a zero-length event. The UIDs of the events
+// are derived from uid, which must be unique per workspace.
+func ScheduleEvents(uid, sleepSchedule, wakeSchedule string, from, to time.Time) ([]Event, error) {
+	if events, ok, err := weeklyEvents(uid, sleepSchedule, wakeSchedule, from); err != nil || ok {
+		return events, err
+	}
+
+	periods, err := validation.HibernationPeriods(sleepSchedule, wakeSchedule, from, to)
+	if err != nil {
+		return nil, err
+	}
+
+	events := make([]Event, 0, len(periods))
+	for _, p := range periods {
+		events = append(events, Event{
+			UID:      fmt.Sprintf("%s-%s", uid, p.Start.UTC().Format("20060102T150405Z")),
+			Start:    p.Start,
+			Duration: periodDuration(p),
+		})
+	}
+	return events, nil
+}
+
+// weeklyEvents builds weekly recurring events, reporting false when the
+// schedule pair cannot be expressed as weekly recurrences
+func weeklyEvents(uid, sleepSchedule, wakeSchedule string, from time.Time) ([]Event, bool, error) {
+	zone := validation.ScheduleTimezone(sleepSchedule)
+	if !isWeekly(sleepSchedule) {
+		return nil, false, nil
+	}
+	if wakeSchedule != "" && (!isWeekly(wakeSchedule) || validation.ScheduleTimezone(wakeSchedule) != zone) {
+		return nil, false, nil
+	}
+
+	location, err := time.LoadLocation(zone)
+	if err != nil {
+		return nil, false, fmt.Errorf("invalid schedule timezone %q: %w", zone, err)
+	}
+
+	periods, err := validation.HibernationPeriods(sleepSchedule, wakeSchedule, from.Add(-week), from)
+	if err != nil {
+		return nil, false, err
+	}
+
+	// Periods with the same wall clock start and end share a recurrence; a
+	// weekend hibernation usually lasts longer than the ones between
+	// weekdays. Comparing absolute lengths instead would split a daily
+	// series at a DST change in the sampled week.
+	type wallClock struct {
+		start    time.Duration
+		duration time.Duration
+	}
+	type series struct {
+		start    time.Time
+		duration time.Duration
+		days     []time.Weekday
+	}
+	var group []*series
+	byWallClock := make(map[wallClock]*series)
+	for _, p := range periods {
+		start := p.Start.In(location)
+		key := wallClock{start: timeOfDay(start)}
+		if !p.End.IsZero() {
+			key.duration = wallTime(p.End.In(location)).Sub(wallTime(start))
+		}
+		s, ok := byWallClock[key]
+		if !ok {
+			s = &series{start: start, duration: key.duration}
+			byWallClock[key] = s
+			group = append(group, s)
+		}
+		s.days = append(s.days, start.Weekday())
+	}
+
+	tzid := zone
+	if location == time.UTC {
+		tzid = ""
+	}
+
+	events := make([]Event, 0, len(group))
+	for i, s := range group {
+		sort.Slice(s.days, func(a, b int) bool { return s.days[a] < s.days[b] })
+		codes := make([]string, 0, len(s.days))
+		for _, day := range s.days {
+			codes = append(codes, weekdayCodes[day])
+		}
+
+		events = append(events, Event{
+			UID:      fmt.Sprintf("%s-%d", uid, i+1),
+			Start:    s.start,
+			Duration: s.duration,
+			RRule:    "FREQ=WEEKLY;BYDAY=" + strings.Join(codes, ","),
+			TZID:     tzid,
+		})
+	}
+	return events, true, nil
+}
+
+// isWeekly reports whether a schedule fires at one time of day on a set of
+// weekdays, which is what a weekly RRULE with BYDAY can express
+func isWeekly(schedule string) bool {
+	sched, err := validation.ParseSchedule(schedule)
+	if err != nil {
+		return false
+	}
+	spec, ok := sched.(*cron.SpecSchedule)
+	if !ok {
+		return false
+	}
+
+	return singleValue(spec.Second) && singleValue(spec.Minute) && singleValue(spec.Hour) &&
+		spec.Dom&starBit != 0 && spec.Month&^starBit == allMonths
+}
+
+// singleValue reports whether a cron field matches exactly one value
+func singleValue(bits uint64) bool {
+	return bits&starBit == 0 && bits != 0 && bits&(bits-1) == 0
+}
+
+// wallTime returns the wall clock reading of t as if its zone had no DST
+func wallTime(t time.Time) time.Time {
+	return time.Date(t.Year(), t.Month(), t.Day(), t.Hour(), t.Minute(), t.Second(), 0, time.UTC)
+}
+
+// timeOfDay returns the wall clock time of t since midnight
+func timeOfDay(t time.Time) time.Duration {
+	return time.Duration(t.Hour())*time.Hour + time.Duration(t.Minute())*time.Minute +
+		time.Duration(t.Second())*time.Second
+}
+
+// periodDuration returns the length of a period, zero for an open one
+func periodDuration(p validation.HibernationPeriod) time.Duration {
+	if p.End.IsZero() {
+		return 0
+	}
+	return p.End.Sub(p.Start)
+}
@@ -0,0 +1,101 @@
+package calendar
+
+import (
+	"bytes"
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestScheduleEventsAcrossDST(t *testing.T) {
+	tests := []struct {
+		name      string
+		sleep     string
+		wake      string
+		from      time.Time
+		wantRules []string
+		wantDurs  []time.Duration
+	}{
+		{
+			name:      "daily pair after the autumn change",
+			sleep:     "CRON_TZ=Europe/Berlin 0 22 * * *",
+			wake:      "CRON_TZ=Europe/Berlin 0 6 * * *",
+			from:      time.Date(2026, time.October, 26, 12, 0, 0, 0, time.UTC),
+			wantRules: []string{"FREQ=WEEKLY;BYDAY=SU,MO,TU,WE,TH,FR,SA"},
+			wantDurs:  []time.Duration{8 * time.Hour},
+		},
+		{
+			name:      "daily pair after the spring change",
+			sleep:     "CRON_TZ=Europe/Berlin 0 22 * * *",
+			wake:      "CRON_TZ=Europe/Berlin 0 6 * * *",
+			from:      time.Date(2026, time.March, 30, 12, 0, 0, 0, time.UTC),
+			wantRules: []string{"FREQ=WEEKLY;BYDAY=SU,MO,TU,WE,TH,FR,SA"},
+			wantDurs:  []time.Duration{8 * time.Hour},
+		},
+		{
+			name:      "weekday pair after the autumn change",
+			sleep:     "CRON_TZ=Europe/Berlin 0 18 * * 1-5",
+			wake:      "CRON_TZ=Europe/Berlin 0 8 * * 1-5",
+			from:      time.Date(2026, time.October, 27, 12, 0, 0, 0, time.UTC),
+			wantRules: []string{"FREQ=WEEKLY;BYDAY=MO,TU,WE,TH", "FREQ=WEEKLY;BYDAY=FR"},
+			wantDurs:  []time.Duration{14 * time.Hour, 62 * time.Hour},
+		},
+		{
+			name:      "daily pair without DST",
+			sleep:     "0 22 * * *",
+			wake:      "0 6 * * *",
+			from:      time.Date(2026, time.October, 26, 12, 0, 0, 0, time.UTC),
+			wantRules: []string{"FREQ=WEEKLY;BYDAY=SU,MO,TU,WE,TH,FR,SA"},
+			wantDurs:  []time.Duration{8 * time.Hour},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			events, err := ScheduleEvents("ws", tt.sleep, tt.wake, tt.from, tt.from.Add(30*24*time.Hour))
+			if err != nil {
+				t.Fatalf("ScheduleEvents() error = %v", err)
+			}
+
+			var rules []string
+			var durations []time.Duration
+			for _, e := range events {
+				rules = append(rules, e.RRule)
+				durations = append(durations, e.Duration)
+			}
+			if strings.Join(rules, " | ") != strings.Join(tt.wantRules, " | ") {
+				t.Errorf("rules = %v, want %v", rules, tt.wantRules)
+			}
+			for i := range tt.wantDurs {
+				if i >= len(durations) || durations[i] != tt.wantDurs[i] {
+					t.Errorf("durations = %v, want %v", durations, tt.wantDurs)
+					break
+				}
+			}
+		})
+	}
+}
+
+func TestCalendarWritesTimezone(t *testing.T) {
+	events, err := ScheduleEvents("ws", "CRON_TZ=Europe/Berlin 0 22 * * *", "CRON_TZ=Europe/Berlin 0 6 * * *",
+		time.Date(2026, time.October, 26, 12, 0, 0, 0, time.UTC), time.Date(2026, time.November, 26, 0, 0, 0, 0, time.UTC))
+	if err != nil {
+		t.Fatalf("ScheduleEvents() error = %v", err)
+	}
+
+	var b bytes.Buffer
+	if err := (&Calendar{Events: events}).Write(&b); err != nil {
+		t.Fatalf("Write() error = %v", err)
+	}
+
+	for _, want := range []string{
+		"BEGIN:VTIMEZONE\r\nTZID:Europe/Berlin\r\n",
+		"BEGIN:DAYLIGHT\r\nDTSTART:20260329T020000\r\nTZOFFSETFROM:+0100\r\nTZOFFSETTO:+0200\r\nTZNAME:CEST\r\nRRULE:FREQ=YEARLY;BYMONTH=3;BYDAY=-1SU\r\n",
+		"BEGIN:STANDARD\r\nDTSTART:20261025T030000\r\nTZOFFSETFROM:+0200\r\nTZOFFSETTO:+0100\r\nTZNAME:CET\r\nRRULE:FREQ=YEARLY;BYMONTH=10;BYDAY=-1SU\r\n",
+		"DTSTART;TZID=Europe/Berlin:",
+	} {
+		if !strings.Contains(b.String(), want) {
+			t.Errorf("calendar does not contain %q:\n%s", want, b.String())
+		}
+	}
+}
@@ -0,0 +1,182 @@
+package calendar
+
+import (
+	"fmt"
+	"time"
+)
+
+// transitionYears is how many years of explicit transitions are written for
+// zones whose changes do not follow a yearly rule
+const transitionYears = 10
+
+// transition is a change of UTC offset in a zone
+type transition struct {
+	at         time.Time
+	offsetFrom int
+	offsetTo   int
+	name       string
+	dst        bool
+}
+
+// writeTimezone writes the VTIMEZONE component RFC 5545 requires for every
+// TZID an event refers to. Transitions are taken from the Go time zone
+// database starting in the year of from. When the zone switches on the same
+// weekday of the same month every year, like most DST rules, each switch is
+// written once with a yearly RRULE; otherwise the transitions of the next
+// years are listed one by one.
+func writeTimezone(line func(format string, args ...interface{}), tzid string, from time.Time) error {
+	location, err := time.LoadLocation(tzid)
+	if err != nil {
+		return fmt.Errorf("invalid time zone %q: %w", tzid, err)
+	}
+
+	year := from.In(location).Year()
+	transitions := zoneTransitions(location, year, year+transitionYears)
+
+	line("BEGIN:VTIMEZONE")
+	line("TZID:%s", tzid)
+	defer line("END:VTIMEZONE")
+
+	if len(transitions) == 0 {
+		// A zone without changes is a single standard observance
+		name, offset := time.Date(year, time.January, 1, 0, 0, 0, 0, location).Zone()
+		writeObservance(line, transition{
+			at:         time.Date(1970, time.January, 1, 0, 0, 0, 0, time.UTC).Add(-time.Duration(offset) * time.Second),
+			offsetFrom: offset,
+			offsetTo:   offset,
+			name:       name,
+		}, "")
+		return nil
+	}
+
+	// Zones with a yearly rule change twice in the first year, once into
+	// and once out of DST
+	first := transitions[:0:0]
+	for _, t := range transitions {
+		if t.at.In(location).Year() == year {
+			first = append(first, t)
+		}
+	}
+	if rules, ok := yearlyRules(first, transitions, location); ok {
+		for i, t := range first {
+			writeObservance(line, t, rules[i])
+		}
+		return nil
+	}
+
+	for _, t := range transitions {
+		writeObservance(line, t, "")
+	}
+	return nil
+}
+
+// writeObservance writes a STANDARD or DAYLIGHT sub-component. Its DTSTART
+// is the local time of the transition in the offset before it.
+func writeObservance(line func(format string, args ...interface{}), t transition, rrule string) {
+	component := "STANDARD"
+	if t.dst {
+		component = "DAYLIGHT"
+	}
+
+	line("BEGIN:%s", component)
+	line("DTSTART:%s", t.at.UTC().Add(time.Duration(t.offsetFrom)*time.Second).Format("20060102T150405"))
+	line("TZOFFSETFROM:%s", formatOffset(t.offsetFrom))
+	line("TZOFFSETTO:%s", formatOffset(t.offsetTo))
+	if t.name != "" {
+		line("TZNAME:%s", escapeText(t.name))
+	}
+	if rrule != "" {
+		line("RRULE:%s", rrule)
+	}
+	line("END:%s", component)
+}
+
+// zoneTransitions returns the offset changes of location from the start of
+// year first until the start of year last
+func zoneTransitions(location *time.Location, first, last int) []transition {
+	end := time.Date(last, time.January, 1, 0, 0, 0, 0, location)
+
+	var transitions []transition
+	t := time.Date(first, time.January, 1, 0, 0, 0, 0, location)
+	for {
+		_, next := t.ZoneBounds()
+		if next.IsZero() || !next.Before(end) {
+			return transitions
+		}
+
+		_, offsetFrom := t.Zone()
+		after := next.In(location)
+		name, offsetTo := after.Zone()
+		if offsetFrom != offsetTo {
+			transitions = append(transitions, transition{
+				at:         next,
+				offsetFrom: offsetFrom,
+				offsetTo:   offsetTo,
+				name:       name,
+				dst:        after.IsDST(),
+			})
+		}
+		t = next
+	}
+}
+
+// yearlyRules returns a yearly RRULE for each transition of the first year,
+// reporting false unless the rules reproduce every transition
+func yearlyRules(first, all []transition, location *time.Location) ([]string, bool) {
+	if len(first) == 0 || len(all)%len(first) != 0 {
+		return nil, false
+	}
+
+	rules := make([]string, len(first))
+	for i, t := range first {
+		local := t.at.UTC().Add(time.Duration(t.offsetFrom) * time.Second)
+		ordinal := (local.Day()-1)/7 + 1
+		if local.Day()+7 > daysIn(local.Month(), local.Year()) {
+			ordinal = -1
+		}
+		rules[i] = fmt.Sprintf("FREQ=YEARLY;BYMONTH=%d;BYDAY=%d%s", int(local.Month()), ordinal, weekdayCodes[local.Weekday()])
+
+		for j := i; j < len(all); j += len(first) {
+			other := all[j]
+			otherLocal := other.at.UTC().Add(time.Duration(other.offsetFrom) * time.Second)
+			expected := nthWeekday(otherLocal.Year(), local.Month(), local.Weekday(), ordinal)
+			if other.offsetFrom != t.offsetFrom || other.offsetTo != t.offsetTo ||
+				otherLocal.Month() != local.Month() || otherLocal.Day() != expected ||
+				timeOfDay(otherLocal) != timeOfDay(local) {
+				return nil, false
+			}
+		}
+	}
+	return rules, true
+}
+
+// nthWeekday returns the day of the month of the n-th weekday, counting from
+// the end of the month for n = -1
+func nthWeekday(year int, month time.Month, weekday time.Weekday, n int) int {
+	if n < 0 {
+		last := daysIn(month, year)
+		lastWeekday := time.Date(year, month, last, 0, 0, 0, 0, time.UTC).Weekday()
+		return last - (int(lastWeekday)-int(weekday)+7)%7
+	}
+	firstWeekday := time.Date(year, month, 1, 0, 0, 0, 0, time.UTC).Weekday()
+	return 1 + (int(weekday)-int(firstWeekday)+7)%7 + (n-1)*7
+}
+
+// daysIn returns the number of days of a month
+func daysIn(month time.Month, year int) int {
+	return time.Date(year, month+1, 0, 0, 0, 0, 0, time.UTC).Day()
+}
+
+// formatOffset renders a UTC offset in seconds as an RFC 5545 UTC-OFFSET
+func formatOffset(seconds int) string {
+	sign := "+"
+	if seconds < 0 {
+		sign = "-"
+		seconds = -seconds
+	}
+	s := fmt.Sprintf("%s%02d%02d", sign, seconds/3600, seconds/60%60)
+	if seconds%60 != 0 {
+		s += fmt.Sprintf("%02d", seconds%60)
+	}
+	return s
+}
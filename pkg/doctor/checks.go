@@ -0,0 +1,440 @@
+package doctor
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+
+	batchv1 "github.com/forkspacer/forkspacer/api/v1"
+	corev1 "k8s.io/api/core/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	"github.com/forkspacer/cli/pkg/module"
+	"github.com/forkspacer/cli/pkg/validation"
+)
+
+// Keys the operator requires in chart authentication secrets. The username
+// key is optional for both.
+const (
+	repoAuthPasswordKey = "password"
+	gitAuthTokenKey     = "token"
+)
+
+func pass(subject, format string, args ...interface{}) Result {
+	return Result{Subject: subject, Status: StatusPass, Message: fmt.Sprintf(format, args...)}
+}
+
+func warn(subject, hint, format string, args ...interface{}) Result {
+	return Result{Subject: subject, Status: StatusWarn, Message: fmt.Sprintf(format, args...), Hint: hint}
+}
+
+func fail(subject, hint, format string, args ...interface{}) Result {
+	return Result{Subject: subject, Status: StatusFail, Message: fmt.Sprintf(format, args...), Hint: hint}
+}
+
+func workspaceSubject(ws *batchv1.Workspace) string {
+	return fmt.Sprintf("workspace %s/%s", ws.Namespace, ws.Name)
+}
+
+func moduleSubject(mod *batchv1.Module) string {
+	return fmt.Sprintf("module %s/%s", mod.Namespace, mod.Name)
+}
+
+// remoteWorkspace reports whether the modules of a workspace are installed
+// in a cluster reached through a kubeconfig secret rather than this one
+func remoteWorkspace(ws *batchv1.Workspace) bool {
+	return ws.Spec.Connection.Type == batchv1.WorkspaceConnectionTypeKubeconfig
+}
+
+// referencesCheck verifies that the objects referenced by the workspace and
+// its modules exist
+type referencesCheck struct{}
+
+func (referencesCheck) Name() string { return "References" }
+
+func (referencesCheck) Run(ctx context.Context, c client.Client, target *Target) []Result {
+	var results []Result
+	ws := target.Workspace
+
+	if from := ws.Spec.From; from != nil {
+		namespace := from.Namespace
+		if namespace == "" {
+			namespace = "default"
+		}
+		source := &batchv1.Workspace{}
+		err := c.Get(ctx, client.ObjectKey{Name: from.Name, Namespace: namespace}, source)
+		switch {
+		case apierrors.IsNotFound(err):
+			results = append(results, fail(workspaceSubject(ws),
+				"recreate the source workspace or create a new workspace without --from",
+				"forked from %s/%s, which does not exist", namespace, from.Name))
+		case err != nil:
+			results = append(results, warn(workspaceSubject(ws), "check your permissions to read workspaces",
+				"could not read fork source %s/%s: %v", namespace, from.Name, err))
+		default:
+			results = append(results, pass(workspaceSubject(ws), "fork source %s/%s exists", namespace, from.Name))
+		}
+	}
+
+	if len(target.Modules) == 0 {
+		results = append(results, warn(workspaceSubject(ws),
+			"add a module with 'forkspacer module add'",
+			"no modules reference this workspace"))
+		return results
+	}
+	results = append(results, pass(workspaceSubject(ws), "%d module(s) reference this workspace", len(target.Modules)))
+
+	for i := range target.Modules {
+		mod := &target.Modules[i]
+		if mod.Spec.Helm == nil {
+			continue
+		}
+		for _, values := range mod.Spec.Helm.Values {
+			if values.ConfigMap == nil {
+				continue
+			}
+			results = append(results, configMapKeyResult(ctx, c, moduleSubject(mod), "values",
+				values.ConfigMap.Namespace, values.ConfigMap.Name, values.ConfigMap.Key))
+		}
+	}
+
+	return results
+}
+
+// secretsCheck verifies that the secrets and config maps the operator reads
+// credentials and charts from exist and hold the expected keys
+type secretsCheck struct{}
+
+func (secretsCheck) Name() string { return "Secrets" }
+
+func (secretsCheck) Run(ctx context.Context, c client.Client, target *Target) []Result {
+	var results []Result
+	ws := target.Workspace
+
+	if ref := ws.Spec.Connection.SecretReference; ref != nil {
+		key := ref.Key
+		if key == "" {
+			key = "kubeconfig"
+		}
+		namespace := ref.Namespace
+		if namespace == "" {
+			namespace = ws.Namespace
+		}
+		results = append(results, secretKeysResult(ctx, c, workspaceSubject(ws), "connection",
+			namespace, ref.Name, []string{key}))
+	} else if remoteWorkspace(ws) {
+		results = append(results, fail(workspaceSubject(ws),
+			"set spec.connection.secretReference to a secret holding the kubeconfig",
+			"connection type is kubeconfig but no secret is referenced"))
+	}
+
+	for i := range target.Modules {
+		mod := &target.Modules[i]
+		subject := moduleSubject(mod)
+
+		if helm := mod.Spec.Helm; helm != nil {
+			if repo := helm.Chart.Repo; repo != nil && repo.Auth != nil {
+				results = append(results, secretKeysResult(ctx, c, subject, "chart repository auth",
+					repo.Auth.Namespace, repo.Auth.Name,
+					[]string{repoAuthPasswordKey}))
+			}
+			if git := helm.Chart.Git; git != nil && git.Auth != nil && git.Auth.HTTPSSecretRef != nil {
+				ref := git.Auth.HTTPSSecretRef
+				results = append(results, secretKeysResult(ctx, c, subject, "git auth",
+					ref.Namespace, ref.Name,
+					[]string{gitAuthTokenKey}))
+			}
+			if cm := helm.Chart.ConfigMap; cm != nil {
+				results = append(results, configMapKeyResult(ctx, c, subject, "chart",
+					cm.Namespace, cm.Name, cm.Key))
+			}
+		}
+
+		if custom := mod.Spec.Custom; custom != nil {
+			for _, name := range custom.ImagePullSecrets {
+				results = append(results, secretKeysResult(ctx, c, subject, "image pull",
+					mod.Namespace, name, nil))
+			}
+		}
+	}
+
+	if len(results) == 0 {
+		results = append(results, pass(workspaceSubject(ws), "no secrets referenced"))
+	}
+	return results
+}
+
+// secretKeysResult checks that a secret exists with all required keys
+func secretKeysResult(ctx context.Context, c client.Client, subject, purpose, namespace, name string, required []string) Result {
+	secret := &corev1.Secret{}
+	err := c.Get(ctx, client.ObjectKey{Name: name, Namespace: namespace}, secret)
+	if apierrors.IsNotFound(err) {
+		return fail(subject,
+			fmt.Sprintf("create it with 'kubectl create secret generic %s -n %s%s'", name, namespace, literalFlags(required)),
+			"%s secret %s/%s does not exist", purpose, namespace, name)
+	}
+	if err != nil {
+		return warn(subject, "check your permissions to read secrets",
+			"could not read %s secret %s/%s: %v", purpose, namespace, name, err)
+	}
+
+	if missing := missingKeys(secret.Data, required); len(missing) > 0 {
+		return fail(subject,
+			fmt.Sprintf("add the key(s) with 'kubectl edit secret %s -n %s'", name, namespace),
+			"%s secret %s/%s is missing key(s) %s", purpose, namespace, name, strings.Join(missing, ", "))
+	}
+
+	return pass(subject, "%s secret %s/%s exists", purpose, namespace, name)
+}
+
+// configMapKeyResult checks that a config map exists and holds key
+func configMapKeyResult(ctx context.Context, c client.Client, subject, purpose, namespace, name, key string) Result {
+	cm := &corev1.ConfigMap{}
+	err := c.Get(ctx, client.ObjectKey{Name: name, Namespace: namespace}, cm)
+	if apierrors.IsNotFound(err) {
+		return fail(subject,
+			fmt.Sprintf("create it with 'kubectl create configmap %s -n %s --from-file=%s=<file>'", name, namespace, key),
+			"%s config map %s/%s does not exist", purpose, namespace, name)
+	}
+	if err != nil {
+		return warn(subject, "check your permissions to read config maps",
+			"could not read %s config map %s/%s: %v", purpose, namespace, name, err)
+	}
+
+	if _, ok := cm.Data[key]; !ok {
+		if _, ok := cm.BinaryData[key]; !ok {
+			return fail(subject,
+				fmt.Sprintf("add the key with 'kubectl edit configmap %s -n %s'", name, namespace),
+				"%s config map %s/%s has no %q key", purpose, namespace, name, key)
+		}
+	}
+
+	return pass(subject, "%s config map %s/%s exists", purpose, namespace, name)
+}
+
+func missingKeys(data map[string][]byte, keys []string) []string {
+	var missing []string
+	for _, key := range keys {
+		if _, ok := data[key]; !ok {
+			missing = append(missing, key)
+		}
+	}
+	return missing
+}
+
+func literalFlags(keys []string) string {
+	var b strings.Builder
+	for _, key := range keys {
+		fmt.Fprintf(&b, " --from-literal=%s=<%s>", key, key)
+	}
+	return b.String()
+}
+
+// releaseCheck verifies that the Helm release of each Helm module exists and
+// its latest revision is deployed
+type releaseCheck struct{}
+
+func (releaseCheck) Name() string { return "Helm releases" }
+
+func (releaseCheck) Run(ctx context.Context, c client.Client, target *Target) []Result {
+	var results []Result
+
+	for i := range target.Modules {
+		mod := &target.Modules[i]
+		if mod.Spec.Helm == nil {
+			continue
+		}
+		subject := moduleSubject(mod)
+
+		if remoteWorkspace(target.Workspace) {
+			results = append(results, warn(subject,
+				"inspect the release with 'helm status' against the workspace cluster",
+				"release is installed in the workspace's remote cluster and was not checked"))
+			continue
+		}
+
+		release, namespace, ok := module.ReleaseName(mod)
+		if !ok {
+			if mod.Status.Phase == batchv1.ModulePhaseInstalling || mod.Status.Phase == "" {
+				results = append(results, warn(subject, "wait for the operator to install the module",
+					"release not installed yet"))
+			} else {
+				results = append(results, fail(subject,
+					"check the operator logs for installation errors",
+					"no release recorded for a module in phase %s", mod.Status.Phase))
+			}
+			continue
+		}
+
+		results = append(results, releaseResult(ctx, c, subject, namespace, release))
+	}
+
+	return results
+}
+
+// releaseResult reads the Helm storage secrets of a release and reports the
+// status of its latest revision
+func releaseResult(ctx context.Context, c client.Client, subject, namespace, release string) Result {
+	secrets := &corev1.SecretList{}
+	if err := c.List(ctx, secrets, client.InNamespace(namespace),
+		client.MatchingLabels{"owner": "helm", "name": release}); err != nil {
+		return warn(subject, "check your permissions to list secrets",
+			"could not read release %s/%s: %v", namespace, release, err)
+	}
+	if len(secrets.Items) == 0 {
+		return fail(subject,
+			"the release was removed outside of Forkspacer; delete and recreate the module",
+			"release %s/%s does not exist", namespace, release)
+	}
+
+	sort.Slice(secrets.Items, func(i, j int) bool {
+		vi, _ := strconv.Atoi(secrets.Items[i].Labels["version"])
+		vj, _ := strconv.Atoi(secrets.Items[j].Labels["version"])
+		return vi > vj
+	})
+	latest := secrets.Items[0].Labels
+	status := latest["status"]
+	revision := latest["version"]
+
+	switch {
+	case status == "deployed":
+		return pass(subject, "release %s/%s revision %s is deployed", namespace, release, revision)
+	case strings.HasPrefix(status, "pending"):
+		return warn(subject,
+			"if the release stays pending, roll it back with 'helm rollback'",
+			"release %s/%s revision %s is %s", namespace, release, revision, status)
+	default:
+		return fail(subject,
+			fmt.Sprintf("inspect it with 'helm history %s -n %s'", release, namespace),
+			"release %s/%s revision %s is %s", namespace, release, revision, status)
+	}
+}
+
+// podsCheck verifies that the pods of each awake Helm release are running
+// and ready
+type podsCheck struct{}
+
+func (podsCheck) Name() string { return "Pods" }
+
+func (podsCheck) Run(ctx context.Context, c client.Client, target *Target) []Result {
+	var results []Result
+
+	for i := range target.Modules {
+		mod := &target.Modules[i]
+		release, namespace, ok := module.ReleaseName(mod)
+		if !ok || remoteWorkspace(target.Workspace) {
+			continue
+		}
+		subject := moduleSubject(mod)
+
+		if mod.Spec.Hibernated || target.Workspace.Spec.Hibernated {
+			results = append(results, pass(subject, "hibernated, pods are not expected to run"))
+			continue
+		}
+
+		pods := &corev1.PodList{}
+		if err := c.List(ctx, pods, client.InNamespace(namespace),
+			client.MatchingLabels{module.LabelHelmInstance: release}); err != nil {
+			results = append(results, warn(subject, "check your permissions to list pods",
+				"could not list pods of release %s/%s: %v", namespace, release, err))
+			continue
+		}
+		if len(pods.Items) == 0 {
+			results = append(results, warn(subject,
+				fmt.Sprintf("check the workloads with 'kubectl get all -n %s'", namespace),
+				"no pods labeled %s=%s", module.LabelHelmInstance, release))
+			continue
+		}
+
+		healthy := 0
+		for _, pod := range pods.Items {
+			if pod.Status.Phase == corev1.PodSucceeded {
+				continue
+			}
+			if reason, ok := podProblem(&pod); ok {
+				results = append(results, fail(subject,
+					fmt.Sprintf("inspect it with 'kubectl describe pod %s -n %s'", pod.Name, namespace),
+					"pod %s is %s", pod.Name, reason))
+				continue
+			}
+			healthy++
+		}
+		if healthy > 0 {
+			results = append(results, pass(subject, "%d pod(s) running and ready", healthy))
+		}
+	}
+
+	return results
+}
+
+// podProblem describes why a pod is not running and ready
+func podProblem(pod *corev1.Pod) (string, bool) {
+	for _, status := range pod.Status.ContainerStatuses {
+		if waiting := status.State.Waiting; waiting != nil && waiting.Reason != "" {
+			return fmt.Sprintf("waiting: %s (container %s)", waiting.Reason, status.Name), true
+		}
+		if terminated := status.State.Terminated; terminated != nil && terminated.ExitCode != 0 {
+			return fmt.Sprintf("terminated: %s (container %s)", terminated.Reason, status.Name), true
+		}
+	}
+
+	if pod.Status.Phase != corev1.PodRunning {
+		return strings.ToLower(string(pod.Status.Phase)), true
+	}
+	for _, condition := range pod.Status.Conditions {
+		if condition.Type == corev1.PodReady && condition.Status != corev1.ConditionTrue {
+			return "running but not ready", true
+		}
+	}
+	return "", false
+}
+
+// scheduleCheck verifies the auto-hibernation schedules of the workspace
+type scheduleCheck struct{}
+
+func (scheduleCheck) Name() string { return "Schedules" }
+
+func (scheduleCheck) Run(ctx context.Context, c client.Client, target *Target) []Result {
+	ws := target.Workspace
+	subject := workspaceSubject(ws)
+
+	ah := ws.Spec.AutoHibernation
+	if ah == nil || !ah.Enabled {
+		return []Result{pass(subject, "auto-hibernation disabled")}
+	}
+
+	hint := fmt.Sprintf("fix it with 'forkspacer workspace update %s --hibernation-schedule ...'", ws.Name)
+	if err := validation.ValidateCronSchedule(ah.Schedule); err != nil {
+		return []Result{fail(subject, hint, "sleep schedule %q: %v", ah.Schedule, err)}
+	}
+
+	wake := ""
+	if ah.WakeSchedule != nil {
+		wake = *ah.WakeSchedule
+		if err := validation.ValidateCronSchedule(wake); err != nil {
+			return []Result{fail(subject, hint, "wake schedule %q: %v", wake, err)}
+		}
+	}
+
+	analysis, err := validation.AnalyzeSchedulePair(ah.Schedule, wake, time.Now())
+	if err != nil {
+		return []Result{fail(subject, hint, "%v", err)}
+	}
+
+	var results []Result
+	for _, issue := range analysis.Issues {
+		if issue.Severity == validation.SeverityError {
+			results = append(results, fail(subject, hint, "%s", issue.Message))
+		} else {
+			results = append(results, warn(subject, fmt.Sprintf("review it with 'forkspacer schedule explain %q'", ah.Schedule), "%s", issue.Message))
+		}
+	}
+	if len(results) == 0 {
+		results = append(results, pass(subject, "schedules valid"))
+	}
+	return results
+}
@@ -0,0 +1,130 @@
+package doctor
+
+import (
+	"context"
+
+	batchv1 "github.com/forkspacer/forkspacer/api/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	clientgoscheme "k8s.io/client-go/kubernetes/scheme"
+	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	"github.com/forkspacer/cli/pkg/preflight"
+)
+
+// Status is the outcome of a single check result
+type Status string
+
+const (
+	StatusPass Status = "pass"
+	StatusWarn Status = "warn"
+	StatusFail Status = "fail"
+)
+
+// Result is one finding of a check
+type Result struct {
+	Check   string `json:"check"`
+	Subject string `json:"subject"`
+	Status  Status `json:"status"`
+	Message string `json:"message"`
+
+	// Hint suggests how to fix a warning or failure
+	Hint string `json:"hint,omitempty"`
+}
+
+// Target is the workspace being diagnosed together with the modules that
+// reference it
+type Target struct {
+	Workspace *batchv1.Workspace
+	Modules   []batchv1.Module
+}
+
+// Check is a diagnostic run against a target. Checks only read from the
+// cluster.
+type Check interface {
+	// Name is a short title shown above the results of the check
+	Name() string
+
+	Run(ctx context.Context, c client.Client, target *Target) []Result
+}
+
+// Report collects the results of all checks run against a workspace
+type Report struct {
+	Workspace string   `json:"workspace"`
+	Namespace string   `json:"namespace"`
+	Results   []Result `json:"results"`
+}
+
+// Count returns the number of results with the given status
+func (r *Report) Count(status Status) int {
+	count := 0
+	for _, result := range r.Results {
+		if result.Status == status {
+			count++
+		}
+	}
+	return count
+}
+
+// Service runs checks against the cluster
+type Service struct {
+	client client.Client
+}
+
+// NewService creates a new doctor service
+func NewService() (*Service, error) {
+	restConfig, err := ctrl.GetConfig()
+	if err != nil {
+		return nil, err
+	}
+	if err := preflight.EnsureCRDs(restConfig); err != nil {
+		return nil, err
+	}
+
+	scheme := runtime.NewScheme()
+	if err := clientgoscheme.AddToScheme(scheme); err != nil {
+		return nil, err
+	}
+	if err := batchv1.AddToScheme(scheme); err != nil {
+		return nil, err
+	}
+
+	k8sClient, err := client.New(restConfig, client.Options{Scheme: scheme})
+	if err != nil {
+		return nil, err
+	}
+
+	return &Service{
+		client: k8sClient,
+	}, nil
+}
+
+// WorkspaceChecks returns the checks run by 'doctor workspace', in order
+func WorkspaceChecks() []Check {
+	return []Check{
+		referencesCheck{},
+		secretsCheck{},
+		releaseCheck{},
+		podsCheck{},
+		scheduleCheck{},
+	}
+}
+
+// Diagnose runs the checks against a workspace and the modules referencing
+// it. Each result is tagged with the name of the check that produced it.
+func (s *Service) Diagnose(ctx context.Context, target *Target, checks []Check) *Report {
+	report := &Report{
+		Workspace: target.Workspace.Name,
+		Namespace: target.Workspace.Namespace,
+		Results:   []Result{},
+	}
+
+	for _, check := range checks {
+		for _, result := range check.Run(ctx, s.client, target) {
+			result.Check = check.Name()
+			report.Results = append(report.Results, result)
+		}
+	}
+
+	return report
+}
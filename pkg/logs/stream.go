@@ -0,0 +1,133 @@
+package logs
+
+import (
+	"bufio"
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"sync"
+	"time"
+
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/client-go/kubernetes"
+
+	"github.com/forkspacer/cli/pkg/styles"
+)
+
+// maxLineSize is the longest log line that is printed
+const maxLineSize = 1024 * 1024
+
+// Source is a container whose logs are streamed
+type Source struct {
+	Namespace string
+	Pod       string
+	Container string
+
+	// Label prefixes every line of the source
+	Label string
+}
+
+// Options control which log lines are streamed
+type Options struct {
+	Follow bool
+
+	// Since limits the logs to the given period; zero means all logs
+	Since time.Duration
+
+	// Tail limits the logs to the last lines of each container; a negative
+	// value means all lines
+	Tail int64
+}
+
+// Sources returns a source for every container of the pods, or only for the
+// named container when container is not empty. When prefix is not empty it
+// is put in front of the label of each source.
+func Sources(pods []corev1.Pod, container, prefix string) []Source {
+	var sources []Source
+	for _, pod := range pods {
+		for _, c := range pod.Spec.Containers {
+			if container != "" && c.Name != container {
+				continue
+			}
+
+			label := pod.Name
+			if len(pod.Spec.Containers) > 1 {
+				label += "/" + c.Name
+			}
+			if prefix != "" {
+				label = prefix + " " + label
+			}
+
+			sources = append(sources, Source{
+				Namespace: pod.Namespace,
+				Pod:       pod.Name,
+				Container: c.Name,
+				Label:     label,
+			})
+		}
+	}
+	return sources
+}
+
+// Stream streams the logs of all sources concurrently to out. Each line is
+// prefixed with the colored label of its source. Stream returns when all
+// streams have ended or ctx is cancelled, with the errors of the streams
+// that failed.
+func Stream(ctx context.Context, clientset kubernetes.Interface, sources []Source, opts Options, out io.Writer) error {
+	var (
+		mu   sync.Mutex
+		errs []error
+		wg   sync.WaitGroup
+	)
+
+	for i, source := range sources {
+		prefix := styles.PrefixStyle(i).Render("[" + source.Label + "]")
+
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			if err := streamSource(ctx, clientset, source, opts, func(line string) {
+				mu.Lock()
+				defer mu.Unlock()
+				fmt.Fprintf(out, "%s %s\n", prefix, line)
+			}); err != nil && ctx.Err() == nil {
+				mu.Lock()
+				errs = append(errs, fmt.Errorf("%s: %w", source.Label, err))
+				mu.Unlock()
+			}
+		}()
+	}
+
+	wg.Wait()
+	return errors.Join(errs...)
+}
+
+// streamSource calls emit for every log line of a single container
+func streamSource(ctx context.Context, clientset kubernetes.Interface, source Source, opts Options, emit func(string)) error {
+	logOptions := &corev1.PodLogOptions{
+		Container: source.Container,
+		Follow:    opts.Follow,
+	}
+	if opts.Since > 0 {
+		seconds := int64(opts.Since.Seconds())
+		logOptions.SinceSeconds = &seconds
+	}
+	if opts.Tail >= 0 {
+		tail := opts.Tail
+		logOptions.TailLines = &tail
+	}
+
+	stream, err := clientset.CoreV1().Pods(source.Namespace).GetLogs(source.Pod, logOptions).Stream(ctx)
+	if err != nil {
+		return err
+	}
+	defer stream.Close()
+
+	scanner := bufio.NewScanner(stream)
+	scanner.Buffer(make([]byte, 64*1024), maxLineSize)
+	for scanner.Scan() {
+		emit(scanner.Text())
+	}
+	return scanner.Err()
+}
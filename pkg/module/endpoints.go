@@ -0,0 +1,278 @@
+package module
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"strconv"
+	"strings"
+
+	batchv1 "github.com/forkspacer/forkspacer/api/v1"
+	corev1 "k8s.io/api/core/v1"
+	networkingv1 "k8s.io/api/networking/v1"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+)
+
+// Endpoint kinds
+const (
+	EndpointKindService = "Service"
+	EndpointKindIngress = "Ingress"
+)
+
+// Endpoint is a service or ingress of a module's Helm release
+type Endpoint struct {
+	Module    string `json:"module"`
+	Release   string `json:"release"`
+	Kind      string `json:"kind"`
+	Name      string `json:"name"`
+	Namespace string `json:"namespace"`
+
+	// Host is the in-cluster DNS name of a service
+	Host  string         `json:"host,omitempty"`
+	Ports []EndpointPort `json:"ports,omitempty"`
+
+	// External lists the load balancer addresses of a service or ingress
+	External []string `json:"external,omitempty"`
+
+	// URLs lists the URLs routed by an ingress
+	URLs []string `json:"urls,omitempty"`
+}
+
+// EndpointPort is a port of a service
+type EndpointPort struct {
+	Name     string `json:"name,omitempty"`
+	Port     int32  `json:"port"`
+	Protocol string `json:"protocol"`
+	NodePort int32  `json:"nodePort,omitempty"`
+}
+
+// EnvVar is an environment variable derived from an endpoint
+type EnvVar struct {
+	Name  string `json:"name"`
+	Value string `json:"value"`
+}
+
+// Ingresses returns the ingresses of a module's Helm release, sorted by name
+func (s *Service) Ingresses(ctx context.Context, module *batchv1.Module) ([]networkingv1.Ingress, error) {
+	release, namespace, err := releaseOf(module)
+	if err != nil {
+		return nil, err
+	}
+
+	ingresses := &networkingv1.IngressList{}
+	if err := s.client.List(ctx, ingresses, client.InNamespace(namespace),
+		client.MatchingLabels{LabelHelmInstance: release}); err != nil {
+		return nil, err
+	}
+
+	sort.Slice(ingresses.Items, func(i, j int) bool {
+		return ingresses.Items[i].Name < ingresses.Items[j].Name
+	})
+	return ingresses.Items, nil
+}
+
+// Endpoints returns the services followed by the ingresses of a module's
+// Helm release
+func (s *Service) Endpoints(ctx context.Context, module *batchv1.Module) ([]Endpoint, error) {
+	release, _, err := releaseOf(module)
+	if err != nil {
+		return nil, err
+	}
+
+	services, err := s.Services(ctx, module)
+	if err != nil {
+		return nil, err
+	}
+	ingresses, err := s.Ingresses(ctx, module)
+	if err != nil {
+		return nil, err
+	}
+
+	endpoints := make([]Endpoint, 0, len(services)+len(ingresses))
+	for i := range services {
+		endpoints = append(endpoints, ServiceEndpoint(module.Name, release, &services[i]))
+	}
+	for i := range ingresses {
+		endpoints = append(endpoints, IngressEndpoint(module.Name, release, &ingresses[i]))
+	}
+	return endpoints, nil
+}
+
+// ServiceEndpoint describes a service of a module's release
+func ServiceEndpoint(module, release string, service *corev1.Service) Endpoint {
+	endpoint := Endpoint{
+		Module:    module,
+		Release:   release,
+		Kind:      EndpointKindService,
+		Name:      service.Name,
+		Namespace: service.Namespace,
+		Host:      fmt.Sprintf("%s.%s.svc.cluster.local", service.Name, service.Namespace),
+	}
+
+	if service.Spec.Type == corev1.ServiceTypeExternalName {
+		endpoint.Host = service.Spec.ExternalName
+	}
+	for _, port := range service.Spec.Ports {
+		endpoint.Ports = append(endpoint.Ports, EndpointPort{
+			Name:     port.Name,
+			Port:     port.Port,
+			Protocol: string(port.Protocol),
+			NodePort: port.NodePort,
+		})
+	}
+	for _, ingress := range service.Status.LoadBalancer.Ingress {
+		endpoint.External = appendAddress(endpoint.External, ingress.IP, ingress.Hostname)
+	}
+	return endpoint
+}
+
+// IngressEndpoint describes an ingress of a module's release. Rules without
+// a host are routed through the ingress's load balancer address.
+func IngressEndpoint(module, release string, ingress *networkingv1.Ingress) Endpoint {
+	endpoint := Endpoint{
+		Module:    module,
+		Release:   release,
+		Kind:      EndpointKindIngress,
+		Name:      ingress.Name,
+		Namespace: ingress.Namespace,
+	}
+	for _, lb := range ingress.Status.LoadBalancer.Ingress {
+		endpoint.External = appendAddress(endpoint.External, lb.IP, lb.Hostname)
+	}
+
+	tlsHosts := map[string]bool{}
+	for _, tls := range ingress.Spec.TLS {
+		for _, host := range tls.Hosts {
+			tlsHosts[host] = true
+		}
+	}
+
+	seen := map[string]bool{}
+	for _, rule := range ingress.Spec.Rules {
+		host := rule.Host
+		if host == "" {
+			if len(endpoint.External) == 0 {
+				continue
+			}
+			host = endpoint.External[0]
+		}
+
+		scheme := "http"
+		if tlsHosts[rule.Host] {
+			scheme = "https"
+		}
+
+		paths := []string{"/"}
+		if rule.HTTP != nil && len(rule.HTTP.Paths) > 0 {
+			paths = paths[:0]
+			for _, path := range rule.HTTP.Paths {
+				paths = append(paths, path.Path)
+			}
+		}
+		for _, path := range paths {
+			if !strings.HasPrefix(path, "/") {
+				path = "/" + path
+			}
+			url := scheme + "://" + host + path
+			if !seen[url] {
+				seen[url] = true
+				endpoint.URLs = append(endpoint.URLs, url)
+			}
+		}
+	}
+	return endpoint
+}
+
+// appendAddress appends the IP or, when empty, the hostname of a load
+// balancer ingress
+func appendAddress(addresses []string, ip, hostname string) []string {
+	if ip != "" {
+		return append(addresses, ip)
+	}
+	if hostname != "" {
+		return append(addresses, hostname)
+	}
+	return addresses
+}
+
+// EndpointEnv derives environment variables from endpoints. Each endpoint
+// gets a prefix made of its module name and its name without the release
+// prefix Helm charts usually add, so service redis-master of module redis
+// becomes REDIS_MASTER. Services set <PREFIX>_HOST, <PREFIX>_PORT (the first
+// port), <PREFIX>_PORT_<NAME> for each named port of services with several
+// ports, and <PREFIX>_EXTERNAL_HOST for load balancers; ingresses set
+// <PREFIX>_URL. When two endpoints map to the same variable the first wins.
+func EndpointEnv(endpoints []Endpoint) []EnvVar {
+	var vars []EnvVar
+	seen := map[string]bool{}
+	add := func(name, value string) {
+		if value == "" || seen[name] {
+			return
+		}
+		seen[name] = true
+		vars = append(vars, EnvVar{Name: name, Value: value})
+	}
+
+	for _, endpoint := range endpoints {
+		prefix := envPrefix(endpoint)
+		switch endpoint.Kind {
+		case EndpointKindService:
+			add(prefix+"_HOST", endpoint.Host)
+			if len(endpoint.Ports) > 0 {
+				add(prefix+"_PORT", strconv.Itoa(int(endpoint.Ports[0].Port)))
+			}
+			if len(endpoint.Ports) > 1 {
+				for _, port := range endpoint.Ports {
+					if port.Name != "" {
+						add(prefix+"_PORT_"+envName(port.Name), strconv.Itoa(int(port.Port)))
+					}
+				}
+			}
+			if len(endpoint.External) > 0 {
+				add(prefix+"_EXTERNAL_HOST", endpoint.External[0])
+			}
+		case EndpointKindIngress:
+			if len(endpoint.URLs) > 0 {
+				add(prefix+"_URL", endpoint.URLs[0])
+			}
+		}
+	}
+	return vars
+}
+
+// envPrefix returns the variable prefix of an endpoint
+func envPrefix(endpoint Endpoint) string {
+	suffix := endpoint.Name
+	if endpoint.Release != "" {
+		if suffix == endpoint.Release {
+			suffix = ""
+		} else if rest, ok := strings.CutPrefix(suffix, endpoint.Release+"-"); ok {
+			suffix = rest
+		}
+	}
+	if suffix == endpoint.Module {
+		suffix = ""
+	}
+
+	if suffix == "" {
+		return envName(endpoint.Module)
+	}
+	return envName(endpoint.Module + "_" + suffix)
+}
+
+// envName turns a Kubernetes name into an environment variable name
+func envName(name string) string {
+	var b strings.Builder
+	for _, r := range strings.ToUpper(name) {
+		if (r >= 'A' && r <= 'Z') || (r >= '0' && r <= '9') || r == '_' {
+			b.WriteRune(r)
+		} else {
+			b.WriteRune('_')
+		}
+	}
+	result := b.String()
+	if result != "" && result[0] >= '0' && result[0] <= '9' {
+		result = "_" + result
+	}
+	return result
+}
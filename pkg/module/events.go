@@ -0,0 +1,19 @@
+package module
+
+import (
+	"context"
+
+	batchv1 "github.com/forkspacer/forkspacer/api/v1"
+	corev1 "k8s.io/api/core/v1"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+)
+
+// Events returns the Kubernetes events involving a module
+func (s *Service) Events(ctx context.Context, module *batchv1.Module) ([]corev1.Event, error) {
+	events := &corev1.EventList{}
+	err := s.client.List(ctx, events,
+		client.InNamespace(module.Namespace),
+		client.MatchingFields{"involvedObject.uid": string(module.UID)},
+	)
+	return events.Items, err
+}
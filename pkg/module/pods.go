@@ -0,0 +1,42 @@
+package module
+
+import (
+	"context"
+	"sort"
+
+	batchv1 "github.com/forkspacer/forkspacer/api/v1"
+	corev1 "k8s.io/api/core/v1"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+)
+
+// Pods returns the pods of a module's Helm release, sorted by name
+func (s *Service) Pods(ctx context.Context, module *batchv1.Module) ([]corev1.Pod, error) {
+	release, namespace, err := releaseOf(module)
+	if err != nil {
+		return nil, err
+	}
+
+	pods := &corev1.PodList{}
+	if err := s.client.List(ctx, pods, client.InNamespace(namespace),
+		client.MatchingLabels{LabelHelmInstance: release}); err != nil {
+		return nil, err
+	}
+
+	sort.Slice(pods.Items, func(i, j int) bool {
+		return pods.Items[i].Name < pods.Items[j].Name
+	})
+	return pods.Items, nil
+}
+
+// PodReady reports whether a pod is running and passes its readiness checks
+func PodReady(pod *corev1.Pod) bool {
+	if pod.Status.Phase != corev1.PodRunning || pod.DeletionTimestamp != nil {
+		return false
+	}
+	for _, condition := range pod.Status.Conditions {
+		if condition.Type == corev1.PodReady {
+			return condition.Status == corev1.ConditionTrue
+		}
+	}
+	return false
+}
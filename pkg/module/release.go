@@ -0,0 +1,107 @@
+package module
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+
+	batchv1 "github.com/forkspacer/forkspacer/api/v1"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	workspaceService "github.com/forkspacer/cli/pkg/workspace"
+)
+
+// AnnotationManagerData holds the operator's JSON encoded manager state for a module
+const AnnotationManagerData = "forkspacer.com/manager-data"
+
+// LabelHelmInstance is the label Helm charts put on the workloads of a release
+const LabelHelmInstance = "app.kubernetes.io/instance"
+
+// ReplicaCount is the replica count of a workload recorded before hibernation
+type ReplicaCount struct {
+	Namespace string `json:"namespace"`
+	Name      string `json:"name"`
+	Replicas  int32  `json:"replicas"`
+}
+
+// ReplicaHistory lists the replica counts the operator restores on wake
+type ReplicaHistory struct {
+	Deployments  []ReplicaCount `json:"deployments,omitempty"`
+	ReplicaSets  []ReplicaCount `json:"replicaSets,omitempty"`
+	StatefulSets []ReplicaCount `json:"statefulSets,omitempty"`
+}
+
+// ManagerData is the subset of the operator's manager state used by the CLI
+type ManagerData struct {
+	ReleaseName    string          `json:"releaseName,omitempty"`
+	ReplicaHistory *ReplicaHistory `json:"replicaHistory,omitempty"`
+}
+
+// GetManagerData parses the manager state annotation of a module. It returns
+// an empty value if the operator has not recorded any state yet.
+func GetManagerData(module *batchv1.Module) (*ManagerData, error) {
+	data := &ManagerData{}
+
+	value, ok := module.Annotations[AnnotationManagerData]
+	if !ok || value == "" {
+		return data, nil
+	}
+
+	if err := json.Unmarshal([]byte(value), data); err != nil {
+		return nil, fmt.Errorf("invalid %s annotation on module %s/%s: %w",
+			AnnotationManagerData, module.Namespace, module.Name, err)
+	}
+	return data, nil
+}
+
+// ReleaseName returns the Helm release of a Helm module and the namespace it
+// is installed in. It reports false for custom modules and for Helm modules
+// the operator has not installed yet.
+func ReleaseName(module *batchv1.Module) (string, string, bool) {
+	if module.Spec.Helm == nil {
+		return "", "", false
+	}
+	namespace := module.Spec.Helm.GetNamespace()
+
+	if data, err := GetManagerData(module); err == nil && data.ReleaseName != "" {
+		return data.ReleaseName, namespace, true
+	}
+	if module.Spec.Helm.ExistingRelease != nil {
+		return module.Spec.Helm.ExistingRelease.Name, namespace, true
+	}
+	return "", "", false
+}
+
+// releaseOf returns the Helm release of a module and its namespace, or an
+// error explaining why the module has none. Objects of the release are found
+// through the standard Helm instance label, LabelHelmInstance.
+func releaseOf(module *batchv1.Module) (release, namespace string, err error) {
+	release, namespace, ok := ReleaseName(module)
+	if !ok {
+		if module.Spec.Custom != nil {
+			return "", "", fmt.Errorf("module %s/%s is a custom module and has no Helm release", module.Namespace, module.Name)
+		}
+		return "", "", fmt.Errorf("module %s/%s has not been installed yet", module.Namespace, module.Name)
+	}
+	return release, namespace, nil
+}
+
+// CheckLocal returns an error when a module belongs to a workspace with a
+// kubeconfig connection. Its release is installed in the cluster of that
+// kubeconfig, so its pods and services cannot be found in this one. Modules
+// whose workspace cannot be read are assumed to be local.
+func (s *Service) CheckLocal(ctx context.Context, module *batchv1.Module) error {
+	namespace := module.Spec.Workspace.Namespace
+	if namespace == "" {
+		namespace = "default"
+	}
+
+	workspace := &batchv1.Workspace{}
+	if err := s.client.Get(ctx, client.ObjectKey{Name: module.Spec.Workspace.Name, Namespace: namespace}, workspace); err != nil {
+		return nil
+	}
+	if err := workspaceService.CheckLocal(workspace); err != nil {
+		return fmt.Errorf("module %s: %w", module.Name, err)
+	}
+	return nil
+}
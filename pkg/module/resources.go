@@ -0,0 +1,118 @@
+package module
+
+import (
+	"context"
+
+	batchv1 "github.com/forkspacer/forkspacer/api/v1"
+	appsv1 "k8s.io/api/apps/v1"
+	corev1 "k8s.io/api/core/v1"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+)
+
+// ResourceRequests are the CPU and memory requested by the workloads of a
+// module while it is awake
+type ResourceRequests struct {
+	Workloads int     `json:"workloads"`
+	CPUCores  float64 `json:"cpuCores"`
+	MemoryGiB float64 `json:"memoryGiB"`
+}
+
+// Add accumulates other into r
+func (r *ResourceRequests) Add(other ResourceRequests) {
+	r.Workloads += other.Workloads
+	r.CPUCores += other.CPUCores
+	r.MemoryGiB += other.MemoryGiB
+}
+
+// AwakeRequests sums the resource requests of the Deployments, StatefulSets
+// and standalone ReplicaSets of a Helm module's release, the workloads the
+// operator scales down on hibernation. Workloads that are currently scaled
+// to zero are counted with the replicas recorded before hibernation.
+func (s *Service) AwakeRequests(ctx context.Context, module *batchv1.Module) (ResourceRequests, error) {
+	var requests ResourceRequests
+
+	release, namespace, ok := ReleaseName(module)
+	if !ok {
+		return requests, nil
+	}
+
+	data, err := GetManagerData(module)
+	if err != nil {
+		return requests, err
+	}
+	history := data.ReplicaHistory
+	if history == nil {
+		history = &ReplicaHistory{}
+	}
+
+	opts := []client.ListOption{
+		client.InNamespace(namespace),
+		client.MatchingLabels{LabelHelmInstance: release},
+	}
+
+	deployments := &appsv1.DeploymentList{}
+	if err := s.client.List(ctx, deployments, opts...); err != nil {
+		return requests, err
+	}
+	for _, d := range deployments.Items {
+		replicas := awakeReplicas(d.Spec.Replicas, history.Deployments, d.Namespace, d.Name)
+		requests.Add(podRequests(&d.Spec.Template.Spec, replicas))
+	}
+
+	statefulSets := &appsv1.StatefulSetList{}
+	if err := s.client.List(ctx, statefulSets, opts...); err != nil {
+		return requests, err
+	}
+	for _, ss := range statefulSets.Items {
+		replicas := awakeReplicas(ss.Spec.Replicas, history.StatefulSets, ss.Namespace, ss.Name)
+		requests.Add(podRequests(&ss.Spec.Template.Spec, replicas))
+	}
+
+	replicaSets := &appsv1.ReplicaSetList{}
+	if err := s.client.List(ctx, replicaSets, opts...); err != nil {
+		return requests, err
+	}
+	for _, rs := range replicaSets.Items {
+		// ReplicaSets owned by a Deployment are already counted
+		if len(rs.OwnerReferences) > 0 {
+			continue
+		}
+		replicas := awakeReplicas(rs.Spec.Replicas, history.ReplicaSets, rs.Namespace, rs.Name)
+		requests.Add(podRequests(&rs.Spec.Template.Spec, replicas))
+	}
+
+	return requests, nil
+}
+
+// awakeReplicas returns the replica count of a workload while awake
+func awakeReplicas(replicas *int32, history []ReplicaCount, namespace, name string) int32 {
+	current := int32(1)
+	if replicas != nil {
+		current = *replicas
+	}
+	if current > 0 {
+		return current
+	}
+
+	for _, h := range history {
+		if h.Namespace == namespace && h.Name == name {
+			return h.Replicas
+		}
+	}
+	return 0
+}
+
+// podRequests sums the container requests of a pod template times replicas
+func podRequests(spec *corev1.PodSpec, replicas int32) ResourceRequests {
+	var cpuMillis, memoryBytes int64
+	for _, c := range spec.Containers {
+		cpuMillis += c.Resources.Requests.Cpu().MilliValue()
+		memoryBytes += c.Resources.Requests.Memory().Value()
+	}
+
+	return ResourceRequests{
+		Workloads: 1,
+		CPUCores:  float64(cpuMillis) / 1000 * float64(replicas),
+		MemoryGiB: float64(memoryBytes) / (1 << 30) * float64(replicas),
+	}
+}
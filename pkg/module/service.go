@@ -7,21 +7,31 @@ import (
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/runtime"
 	clientgoscheme "k8s.io/client-go/kubernetes/scheme"
+	"k8s.io/client-go/rest"
 	ctrl "sigs.k8s.io/controller-runtime"
 	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	"github.com/forkspacer/cli/pkg/preflight"
 )
 
 // Service provides operations for managing modules
 type Service struct {
-	client client.Client
+	client     client.Client
+	restConfig *rest.Config
 }
 
+// moduleGVK identifies the Module kind on objects returned to callers
+var moduleGVK = batchv1.GroupVersion.WithKind("Module")
+
 // NewService creates a new module service
 func NewService() (*Service, error) {
 	restConfig, err := ctrl.GetConfig()
 	if err != nil {
 		return nil, err
 	}
+	if err := preflight.EnsureCRDs(restConfig); err != nil {
+		return nil, err
+	}
 
 	scheme := runtime.NewScheme()
 	if err := clientgoscheme.AddToScheme(scheme); err != nil {
@@ -37,12 +47,19 @@ func NewService() (*Service, error) {
 	}
 
 	return &Service{
-		client: k8sClient,
+		client:     k8sClient,
+		restConfig: restConfig,
 	}, nil
 }
 
+// RESTConfig returns the cluster configuration of the service, for clients
+// that stream from pods
+func (s *Service) RESTConfig() *rest.Config {
+	return s.restConfig
+}
+
 // Delete deletes a module
-func (s *Service) Delete(ctx context.Context, name string, namespace *string) error {
+func (s *Service) Delete(ctx context.Context, name string, namespace *string, opts ...client.DeleteOption) error {
 	ns := "default"
 	if namespace != nil {
 		ns = *namespace
@@ -55,14 +72,13 @@ func (s *Service) Delete(ctx context.Context, name string, namespace *string) er
 		},
 	}
 
-	return s.client.Delete(ctx, module)
+	return s.client.Delete(ctx, module, opts...)
 }
 
-// List modules with optional namespace filtering
-func (s *Service) List(ctx context.Context, namespace string) (*batchv1.ModuleList, error) {
+// List modules with optional namespace and label filtering
+func (s *Service) List(ctx context.Context, namespace string, opts ...client.ListOption) (*batchv1.ModuleList, error) {
 	modules := &batchv1.ModuleList{}
 
-	var opts []client.ListOption
 	if namespace != "" {
 		opts = append(opts, client.InNamespace(namespace))
 	}
@@ -71,6 +87,24 @@ func (s *Service) List(ctx context.Context, namespace string) (*batchv1.ModuleLi
 	return modules, err
 }
 
+// ListByWorkspace returns the modules in any namespace whose Spec.Workspace
+// references the given workspace
+func (s *Service) ListByWorkspace(ctx context.Context, workspaceName, workspaceNamespace string) ([]batchv1.Module, error) {
+	modules, err := s.List(ctx, "")
+	if err != nil {
+		return nil, err
+	}
+
+	var related []batchv1.Module
+	for _, mod := range modules.Items {
+		if mod.Spec.Workspace.Name == workspaceName && mod.Spec.Workspace.Namespace == workspaceNamespace {
+			related = append(related, mod)
+		}
+	}
+
+	return related, nil
+}
+
 // Get fetches a single module
 func (s *Service) Get(ctx context.Context, name, namespace string) (*batchv1.Module, error) {
 	module := &batchv1.Module{}
@@ -78,6 +112,7 @@ func (s *Service) Get(ctx context.Context, name, namespace string) (*batchv1.Mod
 		Name:      name,
 		Namespace: namespace,
 	}, module)
+	module.SetGroupVersionKind(moduleGVK)
 	return module, err
 }
 
@@ -96,7 +131,44 @@ func (s *Service) CreateExistingHelmRelease(
 	chartSourceGitRevision string,
 	authSecretName string,
 	authSecretNamespace string,
+	opts ...client.CreateOption,
 ) (*batchv1.Module, error) {
+	module := BuildExistingHelmRelease(
+		name,
+		namespace,
+		helmReleaseName,
+		helmReleaseNamespace,
+		workspaceName,
+		workspaceNamespace,
+		hibernated,
+		chartSourceGitRepo,
+		chartSourceGitPath,
+		chartSourceGitRevision,
+		authSecretName,
+		authSecretNamespace,
+	)
+
+	err := s.client.Create(ctx, module, opts...)
+	module.SetGroupVersionKind(moduleGVK)
+	return module, err
+}
+
+// BuildExistingHelmRelease constructs a module that imports an existing Helm
+// release from a Git chart source without sending it to the cluster
+func BuildExistingHelmRelease(
+	name string,
+	namespace string,
+	helmReleaseName string,
+	helmReleaseNamespace string,
+	workspaceName string,
+	workspaceNamespace string,
+	hibernated bool,
+	chartSourceGitRepo string,
+	chartSourceGitPath string,
+	chartSourceGitRevision string,
+	authSecretName string,
+	authSecretNamespace string,
+) *batchv1.Module {
 	chartGit := &batchv1.ModuleSpecHelmChartGit{
 		Repo:     chartSourceGitRepo,
 		Path:     chartSourceGitPath,
@@ -136,8 +208,8 @@ func (s *Service) CreateExistingHelmRelease(
 		},
 	}
 
-	err := s.client.Create(ctx, module)
-	return module, err
+	module.SetGroupVersionKind(moduleGVK)
+	return module
 }
 
 // CreateExistingHelmReleaseWithChartRepo creates a module that imports an existing Helm release using a chart repository
@@ -155,7 +227,44 @@ func (s *Service) CreateExistingHelmReleaseWithChartRepo(
 	chartVersion string,
 	authSecretName string,
 	authSecretNamespace string,
+	opts ...client.CreateOption,
 ) (*batchv1.Module, error) {
+	module := BuildExistingHelmReleaseWithChartRepo(
+		name,
+		namespace,
+		helmReleaseName,
+		helmReleaseNamespace,
+		workspaceName,
+		workspaceNamespace,
+		hibernated,
+		chartRepoURL,
+		chartName,
+		chartVersion,
+		authSecretName,
+		authSecretNamespace,
+	)
+
+	err := s.client.Create(ctx, module, opts...)
+	module.SetGroupVersionKind(moduleGVK)
+	return module, err
+}
+
+// BuildExistingHelmReleaseWithChartRepo constructs a module that imports an
+// existing Helm release from a chart repository without sending it to the cluster
+func BuildExistingHelmReleaseWithChartRepo(
+	name string,
+	namespace string,
+	helmReleaseName string,
+	helmReleaseNamespace string,
+	workspaceName string,
+	workspaceNamespace string,
+	hibernated bool,
+	chartRepoURL string,
+	chartName string,
+	chartVersion string,
+	authSecretName string,
+	authSecretNamespace string,
+) *batchv1.Module {
 	chartRepo := &batchv1.ModuleSpecHelmChartRepo{
 		URL:     chartRepoURL,
 		Chart:   chartName,
@@ -197,6 +306,6 @@ func (s *Service) CreateExistingHelmReleaseWithChartRepo(
 		},
 	}
 
-	err := s.client.Create(ctx, module)
-	return module, err
+	module.SetGroupVersionKind(moduleGVK)
+	return module
 }
@@ -0,0 +1,125 @@
+package module
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"strconv"
+	"strings"
+
+	batchv1 "github.com/forkspacer/forkspacer/api/v1"
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/util/intstr"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+)
+
+// Services returns the services of a module's Helm release, sorted by name
+func (s *Service) Services(ctx context.Context, module *batchv1.Module) ([]corev1.Service, error) {
+	release, namespace, err := releaseOf(module)
+	if err != nil {
+		return nil, err
+	}
+
+	services := &corev1.ServiceList{}
+	if err := s.client.List(ctx, services, client.InNamespace(namespace),
+		client.MatchingLabels{LabelHelmInstance: release}); err != nil {
+		return nil, err
+	}
+
+	sort.Slice(services.Items, func(i, j int) bool {
+		return services.Items[i].Name < services.Items[j].Name
+	})
+	return services.Items, nil
+}
+
+// ServicePods returns the pods selected by a service
+func (s *Service) ServicePods(ctx context.Context, service *corev1.Service) ([]corev1.Pod, error) {
+	if len(service.Spec.Selector) == 0 {
+		return nil, fmt.Errorf("service %s has no selector", service.Name)
+	}
+
+	pods := &corev1.PodList{}
+	if err := s.client.List(ctx, pods, client.InNamespace(service.Namespace),
+		client.MatchingLabels(service.Spec.Selector)); err != nil {
+		return nil, err
+	}
+	return pods.Items, nil
+}
+
+// FindServicePort returns the service and port matching a port number or
+// name. When serviceName is set only that service is considered; otherwise
+// the port must identify a single service.
+func FindServicePort(services []corev1.Service, port, serviceName string) (*corev1.Service, *corev1.ServicePort, error) {
+	type match struct {
+		service *corev1.Service
+		port    *corev1.ServicePort
+	}
+
+	var matches []match
+	for i := range services {
+		svc := &services[i]
+		if serviceName != "" && svc.Name != serviceName {
+			continue
+		}
+		for j := range svc.Spec.Ports {
+			p := &svc.Spec.Ports[j]
+			if p.Name == port || strconv.Itoa(int(p.Port)) == port {
+				matches = append(matches, match{service: svc, port: p})
+			}
+		}
+	}
+
+	switch len(matches) {
+	case 1:
+		return matches[0].service, matches[0].port, nil
+	case 0:
+		if serviceName != "" {
+			return nil, nil, fmt.Errorf("service %s has no port %s (ports: %s)", serviceName, port, servicePorts(services, serviceName))
+		}
+		return nil, nil, fmt.Errorf("no service of the module exposes port %s (ports: %s)", port, servicePorts(services, ""))
+	default:
+		var names []string
+		for _, m := range matches {
+			names = append(names, m.service.Name)
+		}
+		return nil, nil, fmt.Errorf("port %s is exposed by services %s; choose one with --service",
+			port, strings.Join(names, ", "))
+	}
+}
+
+// servicePorts lists the ports of the services as "name:port" for errors
+func servicePorts(services []corev1.Service, serviceName string) string {
+	var ports []string
+	for _, svc := range services {
+		if serviceName != "" && svc.Name != serviceName {
+			continue
+		}
+		for _, p := range svc.Spec.Ports {
+			ports = append(ports, fmt.Sprintf("%s:%d", svc.Name, p.Port))
+		}
+	}
+	if len(ports) == 0 {
+		return "none"
+	}
+	return strings.Join(ports, ", ")
+}
+
+// ContainerPort resolves the target port of a service port to the port of a
+// container in pod
+func ContainerPort(pod *corev1.Pod, port *corev1.ServicePort) (int32, error) {
+	switch {
+	case port.TargetPort.Type == intstr.String && port.TargetPort.StrVal != "":
+		for _, container := range pod.Spec.Containers {
+			for _, containerPort := range container.Ports {
+				if containerPort.Name == port.TargetPort.StrVal {
+					return containerPort.ContainerPort, nil
+				}
+			}
+		}
+		return 0, fmt.Errorf("pod %s has no container port named %s", pod.Name, port.TargetPort.StrVal)
+	case port.TargetPort.IntVal != 0:
+		return port.TargetPort.IntVal, nil
+	default:
+		return port.Port, nil
+	}
+}
@@ -0,0 +1,413 @@
+package operator
+
+import (
+	"bytes"
+	"context"
+	_ "embed"
+	"errors"
+	"fmt"
+	"io"
+	"strings"
+	"time"
+
+	appsv1 "k8s.io/api/apps/v1"
+	corev1 "k8s.io/api/core/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/api/meta"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/util/wait"
+	"k8s.io/apimachinery/pkg/util/yaml"
+	"k8s.io/client-go/discovery"
+	clientgoscheme "k8s.io/client-go/kubernetes/scheme"
+	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	batchv1 "github.com/forkspacer/forkspacer/api/v1"
+
+	"github.com/forkspacer/cli/pkg/preflight"
+)
+
+// installManifest is dist/install.yaml of the forkspacer module in go.mod,
+// refreshed with 'make manifests'. Its controller image is pinned to the
+// module version when the manifest is read, as upstream releases may ship a
+// dist file that still references the previous image.
+//
+//go:embed manifests/install.yaml
+var installManifest []byte
+
+// FieldManager owns the fields applied by the CLI
+const FieldManager = "forkspacer-cli"
+
+// certManagerGroupVersion serves the certificates the operator's webhooks use
+const certManagerGroupVersion = "cert-manager.io/v1"
+
+// CertManagerHint tells the user how to install cert-manager
+const CertManagerHint = "install cert-manager with 'kubectl apply -f " +
+	"https://github.com/cert-manager/cert-manager/releases/download/v1.18.2/cert-manager.yaml'"
+
+// applyOrder ranks kinds that other objects depend on; all other kinds are
+// applied afterwards in manifest order
+var applyOrder = map[string]int{
+	"Namespace":                0,
+	"CustomResourceDefinition": 1,
+	"ServiceAccount":           2,
+	"ClusterRole":              3,
+	"Role":                     3,
+	"ClusterRoleBinding":       4,
+	"RoleBinding":              4,
+}
+
+// customResourceKinds are the kinds served by the operator's CRDs. Modules
+// come first so their releases are uninstalled before their workspaces go.
+var customResourceKinds = []string{"Module", "Workspace"}
+
+// Manifests returns the embedded operator objects in the order they are
+// applied: namespaces, CRDs and RBAC first
+func Manifests() ([]*unstructured.Unstructured, error) {
+	decoder := yaml.NewYAMLOrJSONDecoder(bytes.NewReader(installManifest), 4096)
+
+	var ranked [5][]*unstructured.Unstructured
+	var rest []*unstructured.Unstructured
+	for {
+		obj := &unstructured.Unstructured{}
+		if err := decoder.Decode(&obj.Object); err != nil {
+			if errors.Is(err, io.EOF) {
+				break
+			}
+			return nil, fmt.Errorf("invalid embedded manifest: %w", err)
+		}
+		if len(obj.Object) == 0 {
+			continue
+		}
+
+		if obj.GetKind() == "Deployment" {
+			if err := pinOperatorImage(obj, preflight.APIVersion()); err != nil {
+				return nil, err
+			}
+		}
+
+		if rank, ok := applyOrder[obj.GetKind()]; ok {
+			ranked[rank] = append(ranked[rank], obj)
+		} else {
+			rest = append(rest, obj)
+		}
+	}
+
+	var objects []*unstructured.Unstructured
+	for _, group := range ranked {
+		objects = append(objects, group...)
+	}
+	return append(objects, rest...), nil
+}
+
+// pinOperatorImage tags the operator container of a deployment with version.
+// Builds without a released API version, such as local replaces, keep the
+// manifest's tag.
+func pinOperatorImage(obj *unstructured.Unstructured, version string) error {
+	if !strings.HasPrefix(version, "v") {
+		return nil
+	}
+
+	containers, found, err := unstructured.NestedSlice(obj.Object, "spec", "template", "spec", "containers")
+	if err != nil || !found {
+		return err
+	}
+	for _, c := range containers {
+		container, ok := c.(map[string]interface{})
+		if !ok {
+			continue
+		}
+		image, _ := container["image"].(string)
+		if !preflight.IsOperatorImage(image) {
+			continue
+		}
+		if i := strings.Index(image, "@"); i >= 0 {
+			image = image[:i]
+		}
+		if tag := preflight.ImageTag(image); tag != "" {
+			image = strings.TrimSuffix(image, ":"+tag)
+		}
+		container["image"] = image + ":" + version
+	}
+	return unstructured.SetNestedSlice(obj.Object, containers, "spec", "template", "spec", "containers")
+}
+
+// Deployment returns the namespace and name of the embedded controller
+// deployment and the operator version its image is tagged with
+func Deployment(objects []*unstructured.Unstructured) (namespace, name, version string, err error) {
+	for _, obj := range objects {
+		if obj.GetKind() != "Deployment" {
+			continue
+		}
+		deployment := &appsv1.Deployment{}
+		if err := runtime.DefaultUnstructuredConverter.FromUnstructured(obj.Object, deployment); err != nil {
+			return "", "", "", err
+		}
+		for _, container := range deployment.Spec.Template.Spec.Containers {
+			if preflight.IsOperatorImage(container.Image) {
+				return deployment.Namespace, deployment.Name, preflight.ImageTag(container.Image), nil
+			}
+		}
+	}
+	return "", "", "", fmt.Errorf("embedded manifest has no operator deployment")
+}
+
+// ObjectID returns "Kind namespace/name" or "Kind name" for cluster scoped objects
+func ObjectID(obj client.Object) string {
+	kind := obj.GetObjectKind().GroupVersionKind().Kind
+	if obj.GetNamespace() == "" {
+		return kind + " " + obj.GetName()
+	}
+	return kind + " " + obj.GetNamespace() + "/" + obj.GetName()
+}
+
+// Service installs and removes the operator
+type Service struct {
+	client    client.Client
+	discovery discovery.DiscoveryInterface
+}
+
+// NewService creates a new operator service. Unlike the other services it
+// does not require the Forkspacer CRDs to be installed.
+func NewService() (*Service, error) {
+	restConfig, err := ctrl.GetConfig()
+	if err != nil {
+		return nil, err
+	}
+
+	scheme := runtime.NewScheme()
+	if err := clientgoscheme.AddToScheme(scheme); err != nil {
+		return nil, err
+	}
+
+	k8sClient, err := client.New(restConfig, client.Options{Scheme: scheme})
+	if err != nil {
+		return nil, err
+	}
+
+	discoveryClient, err := discovery.NewDiscoveryClientForConfig(restConfig)
+	if err != nil {
+		return nil, err
+	}
+
+	return &Service{
+		client:    k8sClient,
+		discovery: discoveryClient,
+	}, nil
+}
+
+// Installed returns the operator deployment in the cluster, or nil if the
+// operator is not installed
+func (s *Service) Installed(ctx context.Context) (*preflight.Operator, error) {
+	return preflight.FindOperator(ctx, s.client)
+}
+
+// CertManagerInstalled reports whether the cluster serves the cert-manager
+// API the operator's webhook certificates are issued through
+func (s *Service) CertManagerInstalled() (bool, error) {
+	_, err := s.discovery.ServerResourcesForGroupVersion(certManagerGroupVersion)
+	if apierrors.IsNotFound(err) {
+		return false, nil
+	}
+	if err != nil {
+		return false, err
+	}
+	return true, nil
+}
+
+// Apply server-side applies the objects in order and returns them as
+// returned by the API server. Objects of kinds whose CRDs were applied in the
+// same call are retried until the API server serves them.
+func (s *Service) Apply(ctx context.Context, objects []*unstructured.Unstructured, opts ...client.PatchOption) ([]*unstructured.Unstructured, error) {
+	opts = append([]client.PatchOption{client.FieldOwner(FieldManager), client.ForceOwnership}, opts...)
+
+	applied := make([]*unstructured.Unstructured, 0, len(objects))
+	for _, obj := range objects {
+		obj = obj.DeepCopy()
+		err := wait.PollUntilContextTimeout(ctx, time.Second, 30*time.Second, true, func(ctx context.Context) (bool, error) {
+			err := s.client.Patch(ctx, obj, client.Apply, opts...)
+			if meta.IsNoMatchError(err) {
+				return false, nil
+			}
+			return err == nil, err
+		})
+		if err != nil {
+			return applied, fmt.Errorf("failed to apply %s: %w", ObjectID(obj), err)
+		}
+		applied = append(applied, obj)
+	}
+	return applied, nil
+}
+
+// DryRunApply server-side applies the objects in dry-run mode. A dry run
+// persists nothing, so neither the namespaces nor the CRDs of the manifests
+// exist for the objects that depend on them. Objects in a namespace missing
+// from the cluster and objects of kinds it does not serve are returned as
+// skipped instead of failing the validation of everything else.
+func (s *Service) DryRunApply(ctx context.Context, objects []*unstructured.Unstructured) (applied, skipped []*unstructured.Unstructured, err error) {
+	namespaces := map[string]bool{}
+	for _, obj := range objects {
+		namespace := obj.GetNamespace()
+		if namespace == "" {
+			continue
+		}
+		exists, ok := namespaces[namespace]
+		if !ok {
+			err := s.client.Get(ctx, client.ObjectKey{Name: namespace}, &corev1.Namespace{})
+			if err != nil && !apierrors.IsNotFound(err) {
+				return applied, skipped, fmt.Errorf("failed to look up namespace %s: %w", namespace, err)
+			}
+			exists = err == nil
+			namespaces[namespace] = exists
+		}
+		if !exists {
+			skipped = append(skipped, obj)
+		}
+	}
+
+	for _, obj := range objects {
+		if namespace := obj.GetNamespace(); namespace != "" && !namespaces[namespace] {
+			continue
+		}
+		obj = obj.DeepCopy()
+		err := s.client.Patch(ctx, obj, client.Apply, client.FieldOwner(FieldManager), client.ForceOwnership, client.DryRunAll)
+		if meta.IsNoMatchError(err) {
+			skipped = append(skipped, obj)
+			continue
+		}
+		if err != nil {
+			return applied, skipped, fmt.Errorf("failed to apply %s: %w", ObjectID(obj), err)
+		}
+		applied = append(applied, obj)
+	}
+	return applied, skipped, nil
+}
+
+// Delete deletes the objects in reverse apply order and returns the objects
+// that existed. Missing objects and kinds are skipped.
+func (s *Service) Delete(ctx context.Context, objects []*unstructured.Unstructured, opts ...client.DeleteOption) ([]*unstructured.Unstructured, error) {
+	opts = append([]client.DeleteOption{client.PropagationPolicy("Background")}, opts...)
+
+	var deleted []*unstructured.Unstructured
+	for i := len(objects) - 1; i >= 0; i-- {
+		obj := objects[i].DeepCopy()
+		err := s.client.Delete(ctx, obj, opts...)
+		if apierrors.IsNotFound(err) || meta.IsNoMatchError(err) {
+			continue
+		}
+		if err != nil {
+			return deleted, fmt.Errorf("failed to delete %s: %w", ObjectID(obj), err)
+		}
+		deleted = append(deleted, obj)
+	}
+	return deleted, nil
+}
+
+// CustomResources returns every workspace and module in the cluster, modules
+// first. Kinds the cluster does not serve are skipped.
+func (s *Service) CustomResources(ctx context.Context) ([]*unstructured.Unstructured, error) {
+	var objects []*unstructured.Unstructured
+	for _, kind := range customResourceKinds {
+		list := &unstructured.UnstructuredList{}
+		list.SetGroupVersionKind(batchv1.GroupVersion.WithKind(kind + "List"))
+		err := s.client.List(ctx, list)
+		if meta.IsNoMatchError(err) || apierrors.IsNotFound(err) {
+			continue
+		}
+		if err != nil {
+			return nil, fmt.Errorf("failed to list %ss: %w", strings.ToLower(kind), err)
+		}
+		for i := range list.Items {
+			objects = append(objects, &list.Items[i])
+		}
+	}
+	return objects, nil
+}
+
+// DeleteCustomResources deletes every workspace and module in the cluster
+// and, unless opts request a dry run, waits until they are gone. Their
+// finalizers are removed by the operator once it has uninstalled their Helm
+// releases, so it must still be running; deleting the CRDs or the controller
+// first would leave them terminating forever. It returns the number of
+// objects deleted.
+func (s *Service) DeleteCustomResources(ctx context.Context, timeout time.Duration, opts ...client.DeleteOption) (int, error) {
+	objects, err := s.CustomResources(ctx)
+	if err != nil {
+		return 0, err
+	}
+	if len(objects) == 0 {
+		return 0, nil
+	}
+
+	deleteOpts := &client.DeleteOptions{}
+	deleteOpts.ApplyOptions(opts)
+	for _, obj := range objects {
+		if err := s.client.Delete(ctx, obj, opts...); err != nil && !apierrors.IsNotFound(err) {
+			return 0, fmt.Errorf("failed to delete %s: %w", ObjectID(obj), err)
+		}
+	}
+	if len(deleteOpts.DryRun) > 0 {
+		return len(objects), nil
+	}
+
+	var remaining []*unstructured.Unstructured
+	err = wait.PollUntilContextTimeout(ctx, 2*time.Second, timeout, true, func(ctx context.Context) (bool, error) {
+		remaining, err = s.CustomResources(ctx)
+		return len(remaining) == 0, err
+	})
+	if err != nil {
+		if len(remaining) > 0 {
+			return 0, fmt.Errorf("%d workspace(s) and module(s), such as %s, were not removed within %s; "+
+				"check that the operator is running, as it removes their finalizers", len(remaining), ObjectID(remaining[0]), timeout)
+		}
+		return 0, err
+	}
+	return len(objects), nil
+}
+
+// Exists reports whether an object with the kind, namespace and name of obj exists
+func (s *Service) Exists(ctx context.Context, obj *unstructured.Unstructured) (bool, error) {
+	current := &unstructured.Unstructured{}
+	current.SetGroupVersionKind(obj.GroupVersionKind())
+	err := s.client.Get(ctx, client.ObjectKeyFromObject(obj), current)
+	if apierrors.IsNotFound(err) || meta.IsNoMatchError(err) {
+		return false, nil
+	}
+	if err != nil {
+		return false, err
+	}
+	return true, nil
+}
+
+// WaitForDeployment waits until the deployment reports the Available
+// condition or the timeout expires
+func (s *Service) WaitForDeployment(ctx context.Context, namespace, name string, timeout time.Duration) error {
+	var lastMessage string
+	err := wait.PollUntilContextTimeout(ctx, 2*time.Second, timeout, true, func(ctx context.Context) (bool, error) {
+		deployment := &appsv1.Deployment{}
+		if err := s.client.Get(ctx, client.ObjectKey{Namespace: namespace, Name: name}, deployment); err != nil {
+			if apierrors.IsNotFound(err) {
+				return false, nil
+			}
+			return false, err
+		}
+		for _, condition := range deployment.Status.Conditions {
+			if condition.Type != appsv1.DeploymentAvailable {
+				continue
+			}
+			lastMessage = condition.Message
+			if condition.Status == corev1.ConditionTrue && deployment.Status.ObservedGeneration >= deployment.Generation {
+				return true, nil
+			}
+		}
+		return false, nil
+	})
+	if err != nil {
+		if lastMessage != "" {
+			return fmt.Errorf("deployment %s/%s did not become available within %s: %s", namespace, name, timeout, lastMessage)
+		}
+		return fmt.Errorf("deployment %s/%s did not become available within %s: %w", namespace, name, timeout, err)
+	}
+	return nil
+}
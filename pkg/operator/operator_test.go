@@ -0,0 +1,84 @@
+package operator
+
+import (
+	"context"
+	"os"
+	"testing"
+
+	corev1 "k8s.io/api/core/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	clientgoscheme "k8s.io/client-go/kubernetes/scheme"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/envtest"
+)
+
+// TestDryRunApply validates the embedded manifests against a real API
+// server. It needs the envtest binaries, e.g.
+// KUBEBUILDER_ASSETS=$(setup-envtest use -p path) go test ./pkg/operator
+func TestDryRunApply(t *testing.T) {
+	if os.Getenv("KUBEBUILDER_ASSETS") == "" {
+		t.Skip("KUBEBUILDER_ASSETS is not set")
+	}
+
+	env := &envtest.Environment{}
+	config, err := env.Start()
+	if err != nil {
+		t.Fatalf("failed to start API server: %v", err)
+	}
+	defer env.Stop()
+
+	scheme := runtime.NewScheme()
+	if err := clientgoscheme.AddToScheme(scheme); err != nil {
+		t.Fatal(err)
+	}
+	k8sClient, err := client.New(config, client.Options{Scheme: scheme})
+	if err != nil {
+		t.Fatal(err)
+	}
+	service := &Service{client: k8sClient}
+
+	objects, err := Manifests()
+	if err != nil {
+		t.Fatal(err)
+	}
+	namespace, _, _, err := Deployment(objects)
+	if err != nil {
+		t.Fatal(err)
+	}
+	ctx := context.Background()
+
+	// A fresh cluster: the namespace of the manifests does not exist yet
+	applied, skipped, err := service.DryRunApply(ctx, objects)
+	if err != nil {
+		t.Fatalf("DryRunApply() on a fresh cluster error = %v", err)
+	}
+	for _, obj := range skipped {
+		if obj.GetNamespace() != namespace {
+			t.Errorf("skipped %s, want only objects in %s skipped", ObjectID(obj), namespace)
+		}
+	}
+	if len(applied)+len(skipped) != len(objects) {
+		t.Errorf("applied %d and skipped %d of %d objects", len(applied), len(skipped), len(objects))
+	}
+	err = k8sClient.Get(ctx, client.ObjectKey{Name: namespace}, &corev1.Namespace{})
+	if !apierrors.IsNotFound(err) {
+		t.Errorf("namespace %s after a dry run: error = %v, want NotFound", namespace, err)
+	}
+
+	// Once the namespace exists only kinds the cluster does not serve, like
+	// the cert-manager objects, are skipped
+	if err := k8sClient.Create(ctx, &corev1.Namespace{ObjectMeta: metav1.ObjectMeta{Name: namespace}}); err != nil {
+		t.Fatal(err)
+	}
+	_, skipped, err = service.DryRunApply(ctx, objects)
+	if err != nil {
+		t.Fatalf("DryRunApply() with namespace error = %v", err)
+	}
+	for _, obj := range skipped {
+		if obj.GroupVersionKind().Group != "cert-manager.io" {
+			t.Errorf("skipped %s, want only cert-manager objects skipped", ObjectID(obj))
+		}
+	}
+}
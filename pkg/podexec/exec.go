@@ -0,0 +1,165 @@
+package podexec
+
+import (
+	"context"
+	"net/url"
+	"os"
+	"time"
+
+	"golang.org/x/term"
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/util/httpstream"
+	"k8s.io/client-go/kubernetes"
+	"k8s.io/client-go/kubernetes/scheme"
+	"k8s.io/client-go/rest"
+	"k8s.io/client-go/tools/remotecommand"
+)
+
+// resizeInterval is how often the local terminal size is checked for changes
+const resizeInterval = 250 * time.Millisecond
+
+// Options describe a command run in a container
+type Options struct {
+	Namespace string
+	Pod       string
+	Container string
+	Command   []string
+
+	// Stdin passes os.Stdin to the command
+	Stdin bool
+
+	// TTY allocates a terminal for the command and puts the local terminal
+	// in raw mode while it runs
+	TTY bool
+}
+
+// Run runs a command in a container and connects it to the local standard
+// streams. Errors of commands that exit with a non-zero status implement
+// k8s.io/client-go/util/exec.ExitError.
+func Run(ctx context.Context, config *rest.Config, opts Options) error {
+	clientset, err := kubernetes.NewForConfig(config)
+	if err != nil {
+		return err
+	}
+
+	request := clientset.CoreV1().RESTClient().Post().
+		Resource("pods").
+		Namespace(opts.Namespace).
+		Name(opts.Pod).
+		SubResource("exec").
+		VersionedParams(&corev1.PodExecOptions{
+			Container: opts.Container,
+			Command:   opts.Command,
+			Stdin:     opts.Stdin,
+			Stdout:    true,
+			Stderr:    !opts.TTY,
+			TTY:       opts.TTY,
+		}, scheme.ParameterCodec)
+
+	executor, err := newExecutor(config, request.URL())
+	if err != nil {
+		return err
+	}
+
+	streamOptions := remotecommand.StreamOptions{
+		Stdout: os.Stdout,
+		Tty:    opts.TTY,
+	}
+	if opts.Stdin {
+		streamOptions.Stdin = os.Stdin
+	}
+	if !opts.TTY {
+		streamOptions.Stderr = os.Stderr
+		return executor.StreamWithContext(ctx, streamOptions)
+	}
+
+	fd := int(os.Stdin.Fd())
+	if term.IsTerminal(fd) {
+		state, err := term.MakeRaw(fd)
+		if err != nil {
+			return err
+		}
+		defer term.Restore(fd, state)
+
+		sizes := newSizeQueue(ctx, int(os.Stdout.Fd()))
+		defer sizes.stop()
+		streamOptions.TerminalSizeQueue = sizes
+	}
+
+	return executor.StreamWithContext(ctx, streamOptions)
+}
+
+// newExecutor prefers the WebSocket protocol and falls back to SPDY for API
+// servers that do not support it, like kubectl does
+func newExecutor(config *rest.Config, target *url.URL) (remotecommand.Executor, error) {
+	spdyExecutor, err := remotecommand.NewSPDYExecutor(config, "POST", target)
+	if err != nil {
+		return nil, err
+	}
+	websocketExecutor, err := remotecommand.NewWebSocketExecutor(config, "GET", target.String())
+	if err != nil {
+		return nil, err
+	}
+
+	return remotecommand.NewFallbackExecutor(websocketExecutor, spdyExecutor, func(err error) bool {
+		return httpstream.IsUpgradeFailure(err) || httpstream.IsHTTPSProxyError(err)
+	})
+}
+
+// sizeQueue reports the size of the local terminal to the remote TTY. It
+// polls instead of waiting for SIGWINCH, which does not exist on Windows.
+type sizeQueue struct {
+	sizes chan remotecommand.TerminalSize
+	done  chan struct{}
+}
+
+func newSizeQueue(ctx context.Context, fd int) *sizeQueue {
+	q := &sizeQueue{
+		sizes: make(chan remotecommand.TerminalSize, 1),
+		done:  make(chan struct{}),
+	}
+
+	go func() {
+		defer close(q.sizes)
+		ticker := time.NewTicker(resizeInterval)
+		defer ticker.Stop()
+
+		var last remotecommand.TerminalSize
+		for {
+			if width, height, err := term.GetSize(fd); err == nil {
+				size := remotecommand.TerminalSize{Width: uint16(width), Height: uint16(height)}
+				if size != last {
+					last = size
+					select {
+					case q.sizes <- size:
+					case <-q.done:
+						return
+					}
+				}
+			}
+
+			select {
+			case <-ctx.Done():
+				return
+			case <-q.done:
+				return
+			case <-ticker.C:
+			}
+		}
+	}()
+
+	return q
+}
+
+// Next returns the next terminal size, or nil once the queue is stopped
+func (q *sizeQueue) Next() *remotecommand.TerminalSize {
+	size, ok := <-q.sizes
+	if !ok {
+		return nil
+	}
+	return &size
+}
+
+func (q *sizeQueue) stop() {
+	close(q.done)
+}
@@ -0,0 +1,245 @@
+package portforward
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"net/http"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/client-go/kubernetes"
+	"k8s.io/client-go/rest"
+	"k8s.io/client-go/tools/portforward"
+	"k8s.io/client-go/transport/spdy"
+)
+
+// retryInterval is the pause between reconnection attempts
+const retryInterval = 2 * time.Second
+
+// Target is the pod port connections are forwarded to
+type Target struct {
+	Namespace string
+	Pod       string
+	Port      int32
+}
+
+// Resolver finds the pod to forward to. It is called again after the
+// connection to the previous pod is lost.
+type Resolver func(ctx context.Context) (*Target, error)
+
+// Forwarder forwards a local port to a pod and reconnects to a new pod when
+// the connection is lost, for example after the workload was restarted
+type Forwarder struct {
+	Config    *rest.Config
+	Addresses []string
+
+	// LocalPort is the local port to listen on; zero picks a free port that
+	// is kept across reconnections
+	LocalPort int
+
+	Resolve Resolver
+
+	// OnReady is called whenever forwarding to a target has started
+	OnReady func(localPort int, target *Target)
+
+	// OnRetry is called when resolving or forwarding failed and the
+	// forwarder is about to try again
+	OnRetry func(err error)
+
+	// ErrOut receives errors of individual forwarded connections
+	ErrOut io.Writer
+}
+
+// ParsePorts parses a "[local:]remote" port specification. The remote port
+// may be a number or a port name. Without a colon the local port is the
+// remote port number; ":remote" and named remote ports pick a free local port.
+func ParsePorts(spec string) (local int, remote string, err error) {
+	localPart, remote, found := strings.Cut(spec, ":")
+	if !found {
+		remote = localPart
+		localPart = ""
+	}
+	if remote == "" {
+		return 0, "", fmt.Errorf("invalid port %q (expected [local:]remote)", spec)
+	}
+
+	switch {
+	case localPart != "":
+		local, err = strconv.Atoi(localPart)
+		if err != nil || local < 0 || local > 65535 {
+			return 0, "", fmt.Errorf("invalid local port %q", localPart)
+		}
+	case !found:
+		local, _ = strconv.Atoi(remote)
+	}
+
+	if n, err := strconv.Atoi(remote); err == nil && (n < 1 || n > 65535) {
+		return 0, "", fmt.Errorf("invalid remote port %q", remote)
+	}
+	return local, remote, nil
+}
+
+// Run forwards until ctx is cancelled. The first resolution and the first
+// forward must succeed, so a local port that is already taken is reported
+// instead of retried; once forwarding has started, failures are retried
+// until a target is available again.
+func (f *Forwarder) Run(ctx context.Context) error {
+	clientset, err := kubernetes.NewForConfig(f.Config)
+	if err != nil {
+		return err
+	}
+
+	target, err := f.Resolve(ctx)
+	if err != nil {
+		return err
+	}
+
+	established := false
+	for {
+		ready, err := f.forward(ctx, clientset, target)
+		if ctx.Err() != nil {
+			return nil
+		}
+		established = established || ready
+		if !established {
+			return err
+		}
+		if f.OnRetry != nil {
+			f.OnRetry(err)
+		}
+
+		for {
+			select {
+			case <-ctx.Done():
+				return nil
+			case <-time.After(retryInterval):
+			}
+
+			target, err = f.Resolve(ctx)
+			if err == nil {
+				break
+			}
+			if ctx.Err() != nil {
+				return nil
+			}
+			if f.OnRetry != nil {
+				f.OnRetry(err)
+			}
+		}
+	}
+}
+
+// forward forwards to a single target until the connection is lost or ctx
+// is cancelled. It reports whether the local listeners were ready.
+func (f *Forwarder) forward(ctx context.Context, clientset kubernetes.Interface, target *Target) (bool, error) {
+	transport, upgrader, err := spdy.RoundTripperFor(f.Config)
+	if err != nil {
+		return false, err
+	}
+
+	url := clientset.CoreV1().RESTClient().Post().
+		Resource("pods").
+		Namespace(target.Namespace).
+		Name(target.Pod).
+		SubResource("portforward").
+		URL()
+	dialer := spdy.NewDialer(upgrader, &http.Client{Transport: transport}, http.MethodPost, url)
+
+	addresses := f.Addresses
+	if len(addresses) == 0 {
+		addresses = []string{"localhost"}
+	}
+	ports := []string{fmt.Sprintf("%d:%d", f.LocalPort, target.Port)}
+
+	errOut := f.ErrOut
+	if errOut == nil {
+		errOut = io.Discard
+	}
+
+	stop := make(chan struct{})
+	ready := make(chan struct{})
+	forwarder, err := portforward.NewOnAddresses(dialer, addresses, ports, stop, ready, io.Discard, errOut)
+	if err != nil {
+		return false, err
+	}
+
+	var (
+		wg       sync.WaitGroup
+		stopOnce sync.Once
+		podGone  error
+	)
+	done := make(chan struct{})
+	stopForwarding := func() { stopOnce.Do(func() { close(stop) }) }
+
+	// Stop when ctx is cancelled or the pod goes away, so a restarted
+	// workload is picked up before the next connection to it fails
+	wg.Add(1)
+	go func() {
+		defer wg.Done()
+		ticker := time.NewTicker(retryInterval)
+		defer ticker.Stop()
+		for {
+			select {
+			case <-ctx.Done():
+				stopForwarding()
+				return
+			case <-done:
+				return
+			case <-ticker.C:
+			}
+
+			pod, err := clientset.CoreV1().Pods(target.Namespace).Get(ctx, target.Pod, metav1.GetOptions{})
+			switch {
+			case apierrors.IsNotFound(err):
+				podGone = fmt.Errorf("pod %s was deleted", target.Pod)
+			case err == nil && pod.DeletionTimestamp != nil:
+				podGone = fmt.Errorf("pod %s is terminating", target.Pod)
+			default:
+				continue
+			}
+			stopForwarding()
+			return
+		}
+	}()
+
+	wg.Add(1)
+	go func() {
+		defer wg.Done()
+		select {
+		case <-ready:
+		case <-done:
+			return
+		}
+		if forwarded, err := forwarder.GetPorts(); err == nil && len(forwarded) > 0 {
+			f.LocalPort = int(forwarded[0].Local)
+		}
+		if f.OnReady != nil {
+			f.OnReady(f.LocalPort, target)
+		}
+	}()
+
+	err = forwarder.ForwardPorts()
+	close(done)
+	wg.Wait()
+
+	listening := false
+	select {
+	case <-ready:
+		listening = true
+	default:
+	}
+
+	switch {
+	case err != nil:
+		return listening, err
+	case podGone != nil:
+		return listening, podGone
+	default:
+		return listening, ctx.Err()
+	}
+}
@@ -0,0 +1,367 @@
+package preflight
+
+import (
+	"context"
+	"fmt"
+	"runtime/debug"
+	"sort"
+	"strings"
+	"sync"
+
+	batchv1 "github.com/forkspacer/forkspacer/api/v1"
+	appsv1 "k8s.io/api/apps/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	utilversion "k8s.io/apimachinery/pkg/util/version"
+	"k8s.io/client-go/discovery"
+	clientgoscheme "k8s.io/client-go/kubernetes/scheme"
+	"k8s.io/client-go/rest"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+)
+
+// apiModule is the Go module providing the Forkspacer API types
+const apiModule = "github.com/forkspacer/forkspacer"
+
+// operatorImage is the image repository of the operator, without registry
+const operatorImage = "forkspacer/forkspacer"
+
+// operatorSelector matches the operator's controller manager deployment
+var operatorSelector = client.MatchingLabels{"control-plane": "controller-manager"}
+
+// InstallHint tells the user how to install the operator and its CRDs
+const InstallHint = "install the operator with 'forkspacer operator install'"
+
+// Resources are the plural resource names the CLI requires, by kind
+var Resources = map[string]string{
+	"Workspace": "workspaces",
+	"Module":    "modules",
+}
+
+// Severity tells whether an issue prevents the CLI from working
+type Severity string
+
+const (
+	SeverityError   Severity = "error"
+	SeverityWarning Severity = "warning"
+)
+
+// Issue is a problem found by the preflight
+type Issue struct {
+	Severity Severity `json:"severity"`
+	Message  string   `json:"message"`
+	Hint     string   `json:"hint,omitempty"`
+}
+
+// CRD is the discovered state of a Forkspacer custom resource
+type CRD struct {
+	Kind      string `json:"kind"`
+	Resource  string `json:"resource"`
+	Installed bool   `json:"installed"`
+}
+
+// Operator is the discovered operator deployment
+type Operator struct {
+	Name          string `json:"name"`
+	Namespace     string `json:"namespace"`
+	Image         string `json:"image"`
+	Version       string `json:"version"`
+	Replicas      int32  `json:"replicas"`
+	ReadyReplicas int32  `json:"readyReplicas"`
+}
+
+// Report is the result of a full preflight
+type Report struct {
+	// APIVersion is the version of the Forkspacer API the CLI was built against
+	APIVersion string `json:"apiVersion"`
+
+	// KubernetesVersion is the version reported by the API server
+	KubernetesVersion string `json:"kubernetesVersion,omitempty"`
+
+	Group string `json:"group"`
+
+	// ServedVersions are the versions of the API group served by the cluster
+	ServedVersions []string `json:"servedVersions"`
+
+	CRDs     []CRD     `json:"crds"`
+	Operator *Operator `json:"operator,omitempty"`
+	Issues   []Issue   `json:"issues,omitempty"`
+}
+
+// HasErrors reports whether any issue prevents the CLI from working
+func (r *Report) HasErrors() bool {
+	for _, issue := range r.Issues {
+		if issue.Severity == SeverityError {
+			return true
+		}
+	}
+	return false
+}
+
+// Compatibility summarizes the issues as "compatible", "degraded" when only
+// warnings were found, or "incompatible"
+func (r *Report) Compatibility() string {
+	switch {
+	case r.HasErrors():
+		return "incompatible"
+	case len(r.Issues) > 0:
+		return "degraded"
+	default:
+		return "compatible"
+	}
+}
+
+func (r *Report) addIssue(severity Severity, hint, format string, args ...interface{}) {
+	r.Issues = append(r.Issues, Issue{Severity: severity, Message: fmt.Sprintf(format, args...), Hint: hint})
+}
+
+// APIVersion returns the version of the Forkspacer API module the CLI was
+// built against, or "unknown" if the binary carries no module information
+func APIVersion() string {
+	info, ok := debug.ReadBuildInfo()
+	if !ok {
+		return "unknown"
+	}
+	for _, dep := range info.Deps {
+		if dep.Path != apiModule {
+			continue
+		}
+		if dep.Replace != nil && dep.Replace.Version != "" {
+			return dep.Replace.Version
+		}
+		return dep.Version
+	}
+	return "unknown"
+}
+
+var (
+	ensuredMu sync.Mutex
+	ensured   = make(map[string]bool)
+)
+
+// EnsureCRDs verifies through discovery that the cluster serves the
+// Forkspacer API version and resources the CLI uses. It is called when
+// services are created so a missing installation is reported with an
+// actionable error instead of a "no matches for kind" failure. Successful
+// results are cached per API server.
+func EnsureCRDs(restConfig *rest.Config) error {
+	ensuredMu.Lock()
+	defer ensuredMu.Unlock()
+	if ensured[restConfig.Host] {
+		return nil
+	}
+
+	discoveryClient, err := discovery.NewDiscoveryClientForConfig(restConfig)
+	if err != nil {
+		return err
+	}
+
+	report := &Report{Group: batchv1.GroupVersion.Group}
+	if err := discoverCRDs(discoveryClient, report); err != nil {
+		return err
+	}
+	for _, issue := range report.Issues {
+		if issue.Severity == SeverityError {
+			return fmt.Errorf("%s; %s, then run 'forkspacer check'", issue.Message, issue.Hint)
+		}
+	}
+
+	ensured[restConfig.Host] = true
+	return nil
+}
+
+// Run performs the full preflight: it discovers the served API versions and
+// resources, finds the operator deployment and compares its version with the
+// API version the CLI was built against
+func Run(ctx context.Context, restConfig *rest.Config) (*Report, error) {
+	discoveryClient, err := discovery.NewDiscoveryClientForConfig(restConfig)
+	if err != nil {
+		return nil, err
+	}
+
+	report := &Report{
+		APIVersion: APIVersion(),
+		Group:      batchv1.GroupVersion.Group,
+	}
+
+	serverVersion, err := discoveryClient.ServerVersion()
+	if err != nil {
+		return nil, fmt.Errorf("failed to get server version: %w", err)
+	}
+	report.KubernetesVersion = serverVersion.GitVersion
+
+	if err := discoverCRDs(discoveryClient, report); err != nil {
+		return nil, err
+	}
+
+	scheme := runtime.NewScheme()
+	if err := clientgoscheme.AddToScheme(scheme); err != nil {
+		return nil, err
+	}
+	k8sClient, err := client.New(restConfig, client.Options{Scheme: scheme})
+	if err != nil {
+		return nil, err
+	}
+
+	operator, err := FindOperator(ctx, k8sClient)
+	if err != nil {
+		report.addIssue(SeverityWarning, "check your permissions to list deployments",
+			"could not look up the operator deployment: %v", err)
+		return report, nil
+	}
+	report.Operator = operator
+	checkOperator(report)
+
+	return report, nil
+}
+
+// discoverCRDs records the served versions of the Forkspacer API group and
+// whether the resources the CLI uses are served in its version
+func discoverCRDs(discoveryClient discovery.DiscoveryInterface, report *Report) error {
+	groups, err := discoveryClient.ServerGroups()
+	if err != nil {
+		return fmt.Errorf("failed to discover API groups: %w", err)
+	}
+
+	for _, group := range groups.Groups {
+		if group.Name != batchv1.GroupVersion.Group {
+			continue
+		}
+		for _, version := range group.Versions {
+			report.ServedVersions = append(report.ServedVersions, version.Version)
+		}
+	}
+
+	kinds := make([]string, 0, len(Resources))
+	for kind := range Resources {
+		kinds = append(kinds, kind)
+	}
+	sort.Strings(kinds)
+
+	if len(report.ServedVersions) == 0 {
+		for _, kind := range kinds {
+			report.CRDs = append(report.CRDs, CRD{Kind: kind, Resource: Resources[kind]})
+		}
+		report.addIssue(SeverityError, InstallHint,
+			"the Forkspacer CRDs are not installed in this cluster (API group %s not found)", batchv1.GroupVersion.Group)
+		return nil
+	}
+
+	served := make(map[string]bool)
+	resources, err := discoveryClient.ServerResourcesForGroupVersion(batchv1.GroupVersion.String())
+	if err == nil {
+		for _, resource := range resources.APIResources {
+			served[resource.Name] = true
+		}
+	}
+
+	for _, kind := range kinds {
+		crd := CRD{Kind: kind, Resource: Resources[kind], Installed: served[Resources[kind]]}
+		report.CRDs = append(report.CRDs, crd)
+	}
+
+	if len(served) == 0 {
+		report.addIssue(SeverityError, "upgrade the operator and its CRDs to a release serving "+batchv1.GroupVersion.Version,
+			"the cluster serves %s versions %s but the CLI requires %s",
+			batchv1.GroupVersion.Group, strings.Join(report.ServedVersions, ", "), batchv1.GroupVersion.Version)
+		return nil
+	}
+	for _, crd := range report.CRDs {
+		if !crd.Installed {
+			report.addIssue(SeverityError, InstallHint,
+				"the %s CRD (%s.%s) is not installed", crd.Kind, crd.Resource, batchv1.GroupVersion.Group)
+		}
+	}
+	return nil
+}
+
+// FindOperator returns the operator deployment, or nil if it is not found
+func FindOperator(ctx context.Context, c client.Client) (*Operator, error) {
+	deployments := &appsv1.DeploymentList{}
+	if err := c.List(ctx, deployments, operatorSelector); err != nil {
+		return nil, err
+	}
+
+	for _, deployment := range deployments.Items {
+		for _, container := range deployment.Spec.Template.Spec.Containers {
+			if !IsOperatorImage(container.Image) {
+				continue
+			}
+			operator := &Operator{
+				Name:          deployment.Name,
+				Namespace:     deployment.Namespace,
+				Image:         container.Image,
+				Version:       ImageTag(container.Image),
+				ReadyReplicas: deployment.Status.ReadyReplicas,
+			}
+			if deployment.Spec.Replicas != nil {
+				operator.Replicas = *deployment.Spec.Replicas
+			}
+			return operator, nil
+		}
+	}
+	return nil, nil
+}
+
+// IsOperatorImage reports whether image is the operator image from any registry
+func IsOperatorImage(image string) bool {
+	repository := image
+	if i := strings.Index(repository, "@"); i >= 0 {
+		repository = repository[:i]
+	}
+	if i := strings.LastIndex(repository, ":"); i > strings.LastIndex(repository, "/") {
+		repository = repository[:i]
+	}
+	return repository == operatorImage || strings.HasSuffix(repository, "/"+operatorImage)
+}
+
+// ImageTag returns the tag of an image reference, or "" if it has none
+func ImageTag(image string) string {
+	if i := strings.Index(image, "@"); i >= 0 {
+		image = image[:i]
+	}
+	if i := strings.LastIndex(image, ":"); i > strings.LastIndex(image, "/") {
+		return image[i+1:]
+	}
+	return ""
+}
+
+// checkOperator reports a missing or unavailable operator and compares its
+// version with the API version of the CLI. Versions are compatible when the
+// major and minor versions match; an operator with an older patch version
+// may ignore fields the CLI sets.
+func checkOperator(report *Report) {
+	operator := report.Operator
+	if operator == nil {
+		report.addIssue(SeverityWarning, InstallHint,
+			"no operator deployment found; workspaces and modules will not be reconciled")
+		return
+	}
+
+	if operator.ReadyReplicas == 0 {
+		report.addIssue(SeverityWarning,
+			fmt.Sprintf("inspect it with 'kubectl describe deployment %s -n %s'", operator.Name, operator.Namespace),
+			"operator deployment %s/%s has no ready replicas", operator.Namespace, operator.Name)
+	}
+
+	operatorVersion, err := utilversion.ParseGeneric(operator.Version)
+	if err != nil {
+		report.addIssue(SeverityWarning, "deploy a released operator image to verify compatibility",
+			"cannot compare operator image tag %q with API version %s", operator.Version, report.APIVersion)
+		return
+	}
+	apiVersion, err := utilversion.ParseGeneric(report.APIVersion)
+	if err != nil {
+		return
+	}
+
+	switch {
+	case operatorVersion.Major() != apiVersion.Major() || operatorVersion.Minor() != apiVersion.Minor():
+		report.addIssue(SeverityError,
+			fmt.Sprintf("use a CLI release built for operator %d.%d or upgrade the operator",
+				operatorVersion.Major(), operatorVersion.Minor()),
+			"operator %s is incompatible with the %s API this CLI was built against", operator.Version, report.APIVersion)
+	case operatorVersion.LessThan(apiVersion):
+		report.addIssue(SeverityWarning, "upgrade the operator to use all CLI features",
+			"operator %s is older than the %s API this CLI was built against; newer fields may be ignored",
+			operator.Version, report.APIVersion)
+	}
+}
@@ -0,0 +1,129 @@
+package printer
+
+import (
+	"fmt"
+	"sort"
+	"time"
+
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/util/duration"
+
+	"github.com/forkspacer/cli/pkg/styles"
+)
+
+// Problem is the first failing component found while describing an object
+type Problem struct {
+	Component string `json:"component"`
+	Message   string `json:"message"`
+}
+
+// PrintProblem prints the failing component, or that none was found
+func PrintProblem(problem *Problem) {
+	if problem == nil {
+		fmt.Println(styles.Success("No failing components found"))
+		return
+	}
+	fmt.Println(styles.Error(fmt.Sprintf("%s: %s", problem.Component, problem.Message)))
+}
+
+// SortEvents orders events by the time they were last observed, oldest first
+func SortEvents(events []corev1.Event) {
+	sort.SliceStable(events, func(i, j int) bool {
+		return eventTime(&events[i]).Before(eventTime(&events[j]))
+	})
+}
+
+// PrintEvents prints events kubectl-describe style, with warnings highlighted
+func PrintEvents(events []corev1.Event) {
+	if len(events) == 0 {
+		fmt.Println("  " + styles.MutedStyle.Render("<none>"))
+		return
+	}
+
+	now := time.Now()
+	table := NewTable([]string{"TYPE", "REASON", "AGE", "FROM", "MESSAGE"})
+	for i := range events {
+		event := &events[i]
+
+		age := duration.HumanDuration(now.Sub(eventTime(event)))
+		if event.Count > 1 {
+			age = fmt.Sprintf("%s (x%d)", age, event.Count)
+		}
+
+		from := event.Source.Component
+		if from == "" {
+			from = event.ReportingController
+		}
+
+		eventType := event.Type
+		if eventType == corev1.EventTypeWarning {
+			eventType = styles.WarningStyle.Render(eventType)
+		}
+
+		table.AddRow([]string{eventType, event.Reason, age, from, event.Message})
+	}
+	table.Render()
+}
+
+// PrintConditions prints status conditions, highlighting unhealthy ones
+func PrintConditions(conditions []metav1.Condition) {
+	if len(conditions) == 0 {
+		fmt.Println("  " + styles.MutedStyle.Render("<none>"))
+		return
+	}
+
+	now := time.Now()
+	table := NewTable([]string{"TYPE", "STATUS", "REASON", "AGE", "MESSAGE"})
+	for _, c := range conditions {
+		status := string(c.Status)
+		if ConditionUnhealthy(c) {
+			status = styles.ErrorStyle.Render(status)
+		}
+		table.AddRow([]string{
+			c.Type,
+			status,
+			c.Reason,
+			duration.HumanDuration(now.Sub(c.LastTransitionTime.Time)),
+			c.Message,
+		})
+	}
+	table.Render()
+}
+
+// ConditionUnhealthy reports whether a condition signals a problem: a
+// Degraded condition that is true, or any other condition that is false
+func ConditionUnhealthy(c metav1.Condition) bool {
+	if c.Type == "Degraded" {
+		return c.Status == metav1.ConditionTrue
+	}
+	return c.Status == metav1.ConditionFalse && c.Type != "Progressing"
+}
+
+// LatestWarning returns the most recent Warning event, if any
+func LatestWarning(events []corev1.Event) (*corev1.Event, bool) {
+	var latest *corev1.Event
+	for i := range events {
+		if events[i].Type != corev1.EventTypeWarning {
+			continue
+		}
+		if latest == nil || eventTime(&events[i]).After(eventTime(latest)) {
+			latest = &events[i]
+		}
+	}
+	return latest, latest != nil
+}
+
+// eventTime returns the most recent time an event was observed
+func eventTime(event *corev1.Event) time.Time {
+	switch {
+	case !event.LastTimestamp.IsZero():
+		return event.LastTimestamp.Time
+	case event.Series != nil && !event.Series.LastObservedTime.IsZero():
+		return event.Series.LastObservedTime.Time
+	case !event.EventTime.IsZero():
+		return event.EventTime.Time
+	default:
+		return event.CreationTimestamp.Time
+	}
+}
@@ -0,0 +1,59 @@
+package printer
+
+import (
+	"encoding/json"
+	"fmt"
+
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"sigs.k8s.io/yaml"
+)
+
+// PrintObject writes an object to stdout in the given output format (json|yaml)
+func PrintObject(obj interface{}, format string) error {
+	var data []byte
+	var err error
+
+	switch format {
+	case "json":
+		data, err = json.MarshalIndent(obj, "", "  ")
+		if err == nil {
+			data = append(data, '\n')
+		}
+	case "yaml":
+		data, err = yaml.Marshal(obj)
+	default:
+		return fmt.Errorf("unsupported output format %q (expected json or yaml)", format)
+	}
+
+	if err != nil {
+		return fmt.Errorf("failed to encode object: %w", err)
+	}
+
+	fmt.Print(string(data))
+	return nil
+}
+
+// PrintManifests writes Kubernetes objects to stdout so they can be applied
+// with kubectl or committed to a GitOps repository. yaml output is a stream
+// of documents separated by "---", json output is a v1 List.
+func PrintManifests(objects []*unstructured.Unstructured, format string) error {
+	if format == "json" {
+		items := make([]interface{}, 0, len(objects))
+		for _, obj := range objects {
+			items = append(items, obj.Object)
+		}
+		return PrintObject(map[string]interface{}{
+			"apiVersion": "v1",
+			"kind":       "List",
+			"items":      items,
+		}, format)
+	}
+
+	for _, obj := range objects {
+		fmt.Println("---")
+		if err := PrintObject(obj.Object, format); err != nil {
+			return err
+		}
+	}
+	return nil
+}
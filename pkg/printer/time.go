@@ -0,0 +1,41 @@
+package printer
+
+import (
+	"fmt"
+	"time"
+)
+
+// FormatInstant renders a point in time in local time followed by UTC,
+// unless the local zone is UTC
+func FormatInstant(t time.Time) string {
+	if sameOffset(t, time.Local, time.UTC) {
+		return t.UTC().Format("Mon 2006-01-02 15:04 MST")
+	}
+	return fmt.Sprintf("%s (%s UTC)",
+		t.Local().Format("Mon 2006-01-02 15:04 MST"),
+		t.UTC().Format("15:04"))
+}
+
+// FormatScheduleInstant renders a scheduled instant like FormatInstant and
+// adds the wall clock of the schedule's own zone when it differs from both
+func FormatScheduleInstant(t time.Time, zone string) string {
+	loc, err := time.LoadLocation(zone)
+	if err != nil || sameOffset(t, loc, time.UTC) || sameOffset(t, loc, time.Local) {
+		return FormatInstant(t)
+	}
+
+	zoneClock := fmt.Sprintf("%s %s", t.In(loc).Format("15:04"), zone)
+	if sameOffset(t, time.Local, time.UTC) {
+		return fmt.Sprintf("%s (%s)", t.UTC().Format("Mon 2006-01-02 15:04 MST"), zoneClock)
+	}
+	return fmt.Sprintf("%s (%s UTC, %s)",
+		t.Local().Format("Mon 2006-01-02 15:04 MST"),
+		t.UTC().Format("15:04"), zoneClock)
+}
+
+// sameOffset reports whether two zones show the same wall clock at t
+func sameOffset(t time.Time, a, b *time.Location) bool {
+	_, offsetA := t.In(a).Zone()
+	_, offsetB := t.In(b).Zone()
+	return offsetA == offsetB
+}
@@ -82,6 +82,24 @@ var (
 			Foreground(Muted)
 )
 
+// PrefixColors tell apart interleaved output of several sources, such as
+// the logs of multiple pods
+var PrefixColors = []lipgloss.Color{
+	lipgloss.Color("#06B6D4"), // Cyan
+	lipgloss.Color("#10B981"), // Green
+	lipgloss.Color("#F59E0B"), // Amber
+	lipgloss.Color("#EC4899"), // Pink
+	lipgloss.Color("#3B82F6"), // Blue
+	lipgloss.Color("#A78BFA"), // Violet
+	lipgloss.Color("#84CC16"), // Lime
+	lipgloss.Color("#F97316"), // Orange
+}
+
+// PrefixStyle returns the style of the i-th output source
+func PrefixStyle(i int) lipgloss.Style {
+	return lipgloss.NewStyle().Foreground(PrefixColors[i%len(PrefixColors)])
+}
+
 // Common symbols
 const (
 	SymbolSuccess  = "✓"
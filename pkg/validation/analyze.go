@@ -0,0 +1,307 @@
+package validation
+
+import (
+	"fmt"
+	"sort"
+	"time"
+
+	"github.com/robfig/cron/v3"
+)
+
+// analysisWindow is the period simulated by AnalyzeSchedulePair
+const analysisWindow = 7 * 24 * time.Hour
+
+// maxTransitionsPerWeek caps the simulation; a schedule firing more often
+// than once a minute is not a meaningful hibernation schedule
+const maxTransitionsPerWeek = 7 * 24 * 60
+
+// minAwakePeriod is the shortest awake period that is not reported
+const minAwakePeriod = 30 * time.Minute
+
+// IssueSeverity tells whether a schedule issue rejects the pair
+type IssueSeverity string
+
+const (
+	SeverityError   IssueSeverity = "error"
+	SeverityWarning IssueSeverity = "warning"
+)
+
+// ScheduleIssue is a problem found in a hibernation/wake schedule pair
+type ScheduleIssue struct {
+	Severity IssueSeverity `json:"severity"`
+	Message  string        `json:"message"`
+}
+
+// ScheduleAnalysis summarizes one simulated week of a schedule pair
+type ScheduleAnalysis struct {
+	Sleeps int `json:"sleeps"`
+	Wakes  int `json:"wakes"`
+
+	// Overlaps counts instants at which both schedules fire
+	Overlaps int `json:"overlaps"`
+
+	// AwakeHours is the time per week the schedules leave the workspace
+	// awake. It is only meaningful when ManualWake is false.
+	AwakeHours float64 `json:"awakeHours"`
+	ManualWake bool    `json:"manualWake"`
+
+	Issues []ScheduleIssue `json:"issues,omitempty"`
+}
+
+// HasErrors reports whether any issue rejects the schedule pair
+func (a *ScheduleAnalysis) HasErrors() bool {
+	for _, issue := range a.Issues {
+		if issue.Severity == SeverityError {
+			return true
+		}
+	}
+	return false
+}
+
+func (a *ScheduleAnalysis) addIssue(severity IssueSeverity, format string, args ...interface{}) {
+	a.Issues = append(a.Issues, ScheduleIssue{Severity: severity, Message: fmt.Sprintf(format, args...)})
+}
+
+// AnalyzeSchedulePair simulates a week of transitions starting at from and
+// reports pairs that sleep and wake at the same instant, never fire, or wake
+// more often than they sleep. An empty wakeSchedule means manual wakes only.
+func AnalyzeSchedulePair(sleepSchedule, wakeSchedule string, from time.Time) (*ScheduleAnalysis, error) {
+	schedules, err := parseSchedulePair(sleepSchedule, wakeSchedule)
+	if err != nil {
+		return nil, err
+	}
+
+	analysis := &ScheduleAnalysis{ManualWake: wakeSchedule == ""}
+	from = from.In(ScheduleLocation)
+	end := from.Add(analysisWindow)
+
+	var transitions []Transition
+	for _, kind := range []TransitionKind{TransitionSleep, TransitionWake} {
+		sched, ok := schedules[kind]
+		if !ok {
+			continue
+		}
+
+		count := 0
+		for at := sched.Next(from); !at.IsZero() && at.Before(end); at = sched.Next(at) {
+			if count++; count > maxTransitionsPerWeek {
+				analysis.addIssue(SeverityError, "the %s schedule fires more than once a minute", kind)
+				return analysis, nil
+			}
+			transitions = append(transitions, Transition{Kind: kind, Time: at})
+		}
+
+		if count == 0 {
+			if next := sched.Next(from); next.IsZero() {
+				analysis.addIssue(SeverityError, "the %s schedule never fires", kind)
+			} else {
+				analysis.addIssue(SeverityWarning, "the %s schedule does not fire within a week (next on %s)",
+					kind, next.Format("2006-01-02 15:04 MST"))
+			}
+		}
+
+		if kind == TransitionSleep {
+			analysis.Sleeps = count
+		} else {
+			analysis.Wakes = count
+		}
+	}
+
+	if analysis.ManualWake {
+		return analysis, nil
+	}
+
+	sortTransitions(transitions)
+
+	var firstOverlap time.Time
+	for i := 1; i < len(transitions); i++ {
+		if transitions[i].Time.Equal(transitions[i-1].Time) && transitions[i].Kind != transitions[i-1].Kind {
+			if analysis.Overlaps == 0 {
+				firstOverlap = transitions[i].Time
+			}
+			analysis.Overlaps++
+		}
+	}
+	if analysis.Overlaps > 0 {
+		analysis.addIssue(SeverityError, "sleep and wake fire at the same instant %d time(s) per week, first on %s",
+			analysis.Overlaps, firstOverlap.Format("Mon 2006-01-02 15:04 MST"))
+	}
+
+	if analysis.Wakes > analysis.Sleeps {
+		analysis.addIssue(SeverityError, "the workspace is woken %d times but hibernated only %d times per week",
+			analysis.Wakes, analysis.Sleeps)
+	}
+
+	analysis.AwakeHours, err = simulateAwakeTime(sleepSchedule, wakeSchedule, transitions, from, end, analysis)
+	if err != nil {
+		return nil, err
+	}
+
+	if analysis.Sleeps > 0 && analysis.Wakes > 0 && analysis.AwakeHours == 0 {
+		analysis.addIssue(SeverityError, "the schedules never leave the workspace awake")
+	}
+
+	return analysis, nil
+}
+
+// simulateAwakeTime replays the transitions and returns the awake hours in
+// [from, end), reporting awake periods that are too short to be useful
+func simulateAwakeTime(sleepSchedule, wakeSchedule string, transitions []Transition, from, end time.Time, analysis *ScheduleAnalysis) (float64, error) {
+	awake, _, err := IsAwakeAt(sleepSchedule, wakeSchedule, from)
+	if err != nil {
+		return 0, err
+	}
+
+	awakeTime, shortest := replayTransitions(awake, transitions, from, end)
+	if shortest > 0 && shortest < minAwakePeriod {
+		analysis.addIssue(SeverityWarning, "the workspace is awake for only %s at a time", shortest)
+	}
+
+	return awakeTime.Hours(), nil
+}
+
+// AwakeDuration returns how long a schedule pair leaves a workspace awake in
+// [from, to). The state at from follows the most recent transition and is
+// assumed to be hibernated when there is none. An empty wakeSchedule means
+// manual wakes only, which are not counted.
+func AwakeDuration(sleepSchedule, wakeSchedule string, from, to time.Time) (time.Duration, error) {
+	schedules, err := parseSchedulePair(sleepSchedule, wakeSchedule)
+	if err != nil {
+		return 0, err
+	}
+
+	from = from.In(ScheduleLocation)
+	transitions, err := collectTransitions(schedules, from, to)
+	if err != nil {
+		return 0, err
+	}
+
+	awake, _, err := IsAwakeAt(sleepSchedule, wakeSchedule, from)
+	if err != nil {
+		return 0, err
+	}
+
+	awakeTime, _ := replayTransitions(awake, transitions, from, to)
+	return awakeTime, nil
+}
+
+// HibernationPeriod is a span during which a schedule pair keeps a workspace
+// hibernated. End is zero when the workspace is only woken manually.
+type HibernationPeriod struct {
+	Start time.Time `json:"start"`
+	End   time.Time `json:"end,omitempty"`
+}
+
+// HibernationPeriods returns the hibernated periods that start in [from, to).
+// A period still running at to ends at the next scheduled wake. Hibernations
+// while already hibernated do not start a new period. With an empty
+// wakeSchedule every hibernation is returned as a period without an end.
+func HibernationPeriods(sleepSchedule, wakeSchedule string, from, to time.Time) ([]HibernationPeriod, error) {
+	schedules, err := parseSchedulePair(sleepSchedule, wakeSchedule)
+	if err != nil {
+		return nil, err
+	}
+
+	from = from.In(ScheduleLocation)
+	transitions, err := collectTransitions(schedules, from, to)
+	if err != nil {
+		return nil, err
+	}
+
+	var periods []HibernationPeriod
+	if wakeSchedule == "" {
+		for _, t := range transitions {
+			periods = append(periods, HibernationPeriod{Start: t.Time})
+		}
+		return periods, nil
+	}
+
+	awake, known, err := IsAwakeAt(sleepSchedule, wakeSchedule, from)
+	if err != nil {
+		return nil, err
+	}
+	if !known {
+		awake = true
+	}
+
+	for _, t := range transitions {
+		switch {
+		case t.Kind == TransitionSleep && awake:
+			awake = false
+			periods = append(periods, HibernationPeriod{Start: t.Time})
+		case t.Kind == TransitionWake && !awake:
+			awake = true
+			if len(periods) > 0 {
+				periods[len(periods)-1].End = t.Time
+			}
+		}
+	}
+	if !awake && len(periods) > 0 {
+		last := &periods[len(periods)-1]
+		last.End = schedules[TransitionWake].Next(last.Start)
+	}
+
+	return periods, nil
+}
+
+// collectTransitions returns the sorted transitions in [from, to), failing
+// for schedules that fire more than once a minute
+func collectTransitions(schedules map[TransitionKind]cron.Schedule, from, to time.Time) ([]Transition, error) {
+	limit := int(to.Sub(from).Minutes()) + 1
+
+	var transitions []Transition
+	for kind, sched := range schedules {
+		count := 0
+		for at := sched.Next(from); !at.IsZero() && at.Before(to); at = sched.Next(at) {
+			if count++; count > limit {
+				return nil, fmt.Errorf("the %s schedule fires more than once a minute", kind)
+			}
+			transitions = append(transitions, Transition{Kind: kind, Time: at})
+		}
+	}
+	sortTransitions(transitions)
+
+	return transitions, nil
+}
+
+// sortTransitions orders transitions like LastTransition: on ties the wake
+// counts as later
+func sortTransitions(transitions []Transition) {
+	sort.SliceStable(transitions, func(i, j int) bool {
+		if transitions[i].Time.Equal(transitions[j].Time) {
+			return transitions[i].Kind == TransitionSleep && transitions[j].Kind == TransitionWake
+		}
+		return transitions[i].Time.Before(transitions[j].Time)
+	})
+}
+
+// replayTransitions returns the total awake time in [from, end) and the
+// shortest complete awake period, starting awake or hibernated at from
+func replayTransitions(awake bool, transitions []Transition, from, end time.Time) (total, shortest time.Duration) {
+	var awakeSince time.Time
+	periodStarted := false
+	if awake {
+		awakeSince = from
+	}
+
+	for _, t := range transitions {
+		switch {
+		case t.Kind == TransitionWake && !awake:
+			awake = true
+			awakeSince = t.Time
+			periodStarted = true
+		case t.Kind == TransitionSleep && awake:
+			awake = false
+			period := t.Time.Sub(awakeSince)
+			total += period
+			if periodStarted && (shortest == 0 || period < shortest) {
+				shortest = period
+			}
+		}
+	}
+	if awake {
+		total += end.Sub(awakeSince)
+	}
+
+	return total, shortest
+}
@@ -0,0 +1,113 @@
+package validation
+
+import (
+	"fmt"
+	"slices"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// SchedulePair is a hibernation schedule with its matching wake schedule
+type SchedulePair struct {
+	Sleep string `json:"hibernationSchedule"`
+	Wake  string `json:"wakeSchedule"`
+}
+
+// ParseClock parses a 24-hour HH:MM time of day
+func ParseClock(value string) (hour, minute int, err error) {
+	t, err := time.Parse("15:04", strings.TrimSpace(value))
+	if err != nil {
+		return 0, 0, fmt.Errorf("invalid time %q (expected HH:MM, e.g. 08:30)", value)
+	}
+	return t.Hour(), t.Minute(), nil
+}
+
+// BuildSchedulePair builds cron schedules that wake a workspace at wakeTime
+// and hibernate it at sleepTime on the given working days. A sleep time
+// earlier than the wake time is treated as the next morning, for overnight
+// shifts. A non-empty zone is encoded as a CRON_TZ= prefix.
+func BuildSchedulePair(days []time.Weekday, wakeTime, sleepTime, zone string) (SchedulePair, error) {
+	if len(days) == 0 {
+		return SchedulePair{}, fmt.Errorf("select at least one working day")
+	}
+
+	wakeHour, wakeMinute, err := ParseClock(wakeTime)
+	if err != nil {
+		return SchedulePair{}, err
+	}
+	sleepHour, sleepMinute, err := ParseClock(sleepTime)
+	if err != nil {
+		return SchedulePair{}, err
+	}
+
+	wakeAt := wakeHour*60 + wakeMinute
+	sleepAt := sleepHour*60 + sleepMinute
+	if wakeAt == sleepAt {
+		return SchedulePair{}, fmt.Errorf("wake and sleep time cannot both be %s", formatClock(wakeHour, wakeMinute, 0))
+	}
+
+	sleepDays := days
+	if sleepAt < wakeAt {
+		sleepDays = make([]time.Weekday, len(days))
+		for i, d := range days {
+			sleepDays[i] = (d + 1) % 7
+		}
+	}
+
+	pair := SchedulePair{
+		Sleep: fmt.Sprintf("%d %d * * %s", sleepMinute, sleepHour, formatWeekdays(sleepDays)),
+		Wake:  fmt.Sprintf("%d %d * * %s", wakeMinute, wakeHour, formatWeekdays(days)),
+	}
+
+	if zone != "" {
+		if pair.Sleep, err = WithTimezone(pair.Sleep, zone); err != nil {
+			return SchedulePair{}, err
+		}
+		if pair.Wake, err = WithTimezone(pair.Wake, zone); err != nil {
+			return SchedulePair{}, err
+		}
+	}
+
+	if err := ValidateCronSchedule(pair.Sleep); err != nil {
+		return SchedulePair{}, err
+	}
+	if err := ValidateCronSchedule(pair.Wake); err != nil {
+		return SchedulePair{}, err
+	}
+
+	return pair, nil
+}
+
+// formatWeekdays renders days as a cron day-of-week field, collapsing runs
+// into ranges ("1-5", "0,6") and using * for the whole week
+func formatWeekdays(days []time.Weekday) string {
+	values := make([]int, 0, len(days))
+	for _, d := range days {
+		if !slices.Contains(values, int(d)) {
+			values = append(values, int(d))
+		}
+	}
+	slices.Sort(values)
+
+	if len(values) == 7 {
+		return "*"
+	}
+
+	var parts []string
+	for i := 0; i < len(values); {
+		j := i
+		for j+1 < len(values) && values[j+1] == values[j]+1 {
+			j++
+		}
+		if j-i >= 2 {
+			parts = append(parts, fmt.Sprintf("%d-%d", values[i], values[j]))
+		} else {
+			for k := i; k <= j; k++ {
+				parts = append(parts, strconv.Itoa(values[k]))
+			}
+		}
+		i = j + 1
+	}
+	return strings.Join(parts, ",")
+}
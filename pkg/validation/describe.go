@@ -0,0 +1,290 @@
+package validation
+
+import (
+	"fmt"
+	"slices"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/robfig/cron/v3"
+)
+
+// starBit marks a field written as * or ? in robfig/cron's bit sets
+const starBit = 1 << 63
+
+// maxListedTimes is the largest number of clock times listed individually
+// before a schedule is described field by field
+const maxListedTimes = 4
+
+var weekdayNames = []string{"Sunday", "Monday", "Tuesday", "Wednesday", "Thursday", "Friday", "Saturday"}
+
+// DescribeSchedule turns a cron schedule into an English description such as
+// "at 18:00 on weekdays". Seconds fields, @descriptors, @every and
+// CRON_TZ=/TZ= prefixes are supported.
+func DescribeSchedule(schedule string) (string, error) {
+	sched, err := ParseSchedule(schedule)
+	if err != nil {
+		return "", err
+	}
+
+	var description string
+	switch s := sched.(type) {
+	case *cron.SpecSchedule:
+		description = describeSpec(s)
+	case cron.ConstantDelaySchedule:
+		description = "every " + formatDelay(s.Delay)
+	default:
+		return "", fmt.Errorf("unsupported schedule type %T", sched)
+	}
+
+	if zone, _ := SplitScheduleTimezone(schedule); zone != "" {
+		description += " (" + zone + ")"
+	}
+	return description, nil
+}
+
+func describeSpec(s *cron.SpecSchedule) string {
+	seconds := bitValues(s.Second, 0, 59)
+	minutes := bitValues(s.Minute, 0, 59)
+	hours := bitValues(s.Hour, 0, 23)
+
+	description, atClock := describeTime(seconds, minutes, hours)
+
+	days := describeDays(s)
+	if days == "" && atClock {
+		days = "every day"
+	}
+	if days != "" {
+		description += " " + days
+	}
+
+	if months := bitValues(s.Month, 1, 12); len(months) < 12 {
+		description += " " + describeMonths(months)
+	}
+
+	return description
+}
+
+// describeTime describes the second, minute and hour fields. The second
+// result reports whether the description lists clock times.
+func describeTime(seconds, minutes, hours []int) (string, bool) {
+	if len(seconds) == 1 && len(minutes) < 60 && len(hours) < 24 && len(minutes)*len(hours) <= maxListedTimes {
+		var clocks []string
+		for _, h := range hours {
+			for _, m := range minutes {
+				clocks = append(clocks, formatClock(h, m, seconds[0]))
+			}
+		}
+		return "at " + joinList(clocks), true
+	}
+
+	var phrases []string
+	if !(len(seconds) == 1 && seconds[0] == 0) {
+		phrases = append(phrases, fieldPhrase(seconds, 0, 59, "second"))
+	}
+
+	finerRepeats := len(phrases) > 0 && strings.HasPrefix(phrases[0], "every")
+	if len(minutes) < 60 || !finerRepeats {
+		phrases = append(phrases, fieldPhrase(minutes, 0, 59, "minute"))
+	}
+
+	finerRepeats = strings.HasPrefix(phrases[len(phrases)-1], "every")
+	if len(hours) < 24 || !finerRepeats {
+		phrases = append(phrases, fieldPhrase(hours, 0, 23, "hour"))
+	}
+
+	// "every 15 minutes during hours 9 through 17", "at minute 0 of every hour"
+	description := phrases[0]
+	if !strings.HasPrefix(description, "every") {
+		description = "at " + description
+	}
+	for i := 1; i < len(phrases); i++ {
+		connector := " of "
+		if strings.HasPrefix(phrases[i-1], "every") {
+			connector = " during "
+		}
+		description += connector + phrases[i]
+	}
+	return description, false
+}
+
+// describeDays describes the day-of-month and day-of-week fields. As in
+// cron, when both are restricted a day matching either one fires.
+func describeDays(s *cron.SpecSchedule) string {
+	dom := bitValues(s.Dom, 1, 31)
+	dow := bitValues(s.Dow, 0, 6)
+
+	domStar := s.Dom&starBit != 0
+	dowStar := s.Dow&starBit != 0
+
+	var phrases []string
+	if !domStar && len(dom) < 31 {
+		phrases = append(phrases, describeDaysOfMonth(dom))
+	}
+	if !dowStar && len(dow) < 7 {
+		phrases = append(phrases, describeWeekdays(dow))
+	}
+
+	// Both restricted means either one matches; a full field on one side
+	// makes the schedule fire every day
+	if !domStar && !dowStar && len(phrases) < 2 {
+		return ""
+	}
+	return strings.Join(phrases, " or ")
+}
+
+func describeDaysOfMonth(days []int) string {
+	if step, ok := stepOf(days, 1, 31); ok {
+		return fmt.Sprintf("on every %s day of the month", ordinal(step))
+	}
+	if len(days) == 1 {
+		return fmt.Sprintf("on the %s of the month", ordinal(days[0]))
+	}
+	if isRange(days) {
+		return fmt.Sprintf("on the %s through %s of the month", ordinal(days[0]), ordinal(days[len(days)-1]))
+	}
+
+	names := make([]string, len(days))
+	for i, d := range days {
+		names[i] = ordinal(d)
+	}
+	return fmt.Sprintf("on the %s of the month", joinList(names))
+}
+
+func describeWeekdays(days []int) string {
+	switch {
+	case slices.Equal(days, []int{1, 2, 3, 4, 5}):
+		return "on weekdays"
+	case slices.Equal(days, []int{0, 6}):
+		return "on weekends"
+	case len(days) > 2 && isRange(days):
+		return fmt.Sprintf("on %s through %s", weekdayNames[days[0]], weekdayNames[days[len(days)-1]])
+	}
+
+	names := make([]string, len(days))
+	for i, d := range days {
+		names[i] = weekdayNames[d] + "s"
+	}
+	return "on " + joinList(names)
+}
+
+func describeMonths(months []int) string {
+	if step, ok := stepOf(months, 1, 12); ok {
+		return fmt.Sprintf("every %d months", step)
+	}
+	if len(months) > 2 && isRange(months) {
+		return fmt.Sprintf("from %s through %s", time.Month(months[0]), time.Month(months[len(months)-1]))
+	}
+
+	names := make([]string, len(months))
+	for i, m := range months {
+		names[i] = time.Month(m).String()
+	}
+	return "in " + joinList(names)
+}
+
+// fieldPhrase describes a single time field, e.g. "every 15 minutes",
+// "hours 9 through 17" or "minute 30"
+func fieldPhrase(values []int, lo, hi int, unit string) string {
+	switch {
+	case len(values) == hi-lo+1:
+		return "every " + unit
+	case len(values) == 1:
+		return fmt.Sprintf("%s %d", unit, values[0])
+	}
+
+	if step, ok := stepOf(values, lo, hi); ok {
+		return fmt.Sprintf("every %d %ss", step, unit)
+	}
+	if isRange(values) {
+		return fmt.Sprintf("%ss %d through %d", unit, values[0], values[len(values)-1])
+	}
+
+	names := make([]string, len(values))
+	for i, v := range values {
+		names[i] = strconv.Itoa(v)
+	}
+	return fmt.Sprintf("%ss %s", unit, joinList(names))
+}
+
+// bitValues lists the values in [lo, hi] set in a cron field bit set
+func bitValues(bits uint64, lo, hi int) []int {
+	var values []int
+	for v := lo; v <= hi; v++ {
+		if bits&(1<<uint(v)) != 0 {
+			values = append(values, v)
+		}
+	}
+	return values
+}
+
+// stepOf reports whether values are lo, lo+step, lo+2*step... up to hi
+func stepOf(values []int, lo, hi int) (int, bool) {
+	if len(values) < 2 || values[0] != lo {
+		return 0, false
+	}
+	step := values[1] - values[0]
+	if step < 2 {
+		return 0, false
+	}
+	for i := 1; i < len(values); i++ {
+		if values[i]-values[i-1] != step {
+			return 0, false
+		}
+	}
+	return step, values[len(values)-1]+step > hi
+}
+
+func isRange(values []int) bool {
+	for i := 1; i < len(values); i++ {
+		if values[i] != values[i-1]+1 {
+			return false
+		}
+	}
+	return len(values) > 1
+}
+
+func formatClock(hour, minute, second int) string {
+	if second != 0 {
+		return fmt.Sprintf("%02d:%02d:%02d", hour, minute, second)
+	}
+	return fmt.Sprintf("%02d:%02d", hour, minute)
+}
+
+// formatDelay prints a duration without trailing zero units ("1h30m")
+func formatDelay(d time.Duration) string {
+	s := d.String()
+	if strings.HasSuffix(s, "m0s") {
+		s = strings.TrimSuffix(s, "0s")
+	}
+	if strings.HasSuffix(s, "h0m") {
+		s = strings.TrimSuffix(s, "0m")
+	}
+	return s
+}
+
+func ordinal(n int) string {
+	suffix := "th"
+	switch {
+	case n%100 >= 11 && n%100 <= 13:
+	case n%10 == 1:
+		suffix = "st"
+	case n%10 == 2:
+		suffix = "nd"
+	case n%10 == 3:
+		suffix = "rd"
+	}
+	return strconv.Itoa(n) + suffix
+}
+
+// joinList joins items as "a", "a and b" or "a, b and c"
+func joinList(items []string) string {
+	switch len(items) {
+	case 0:
+		return ""
+	case 1:
+		return items[0]
+	}
+	return strings.Join(items[:len(items)-1], ", ") + " and " + items[len(items)-1]
+}
@@ -0,0 +1,174 @@
+package validation
+
+import (
+	"fmt"
+	"sort"
+	"time"
+
+	"github.com/robfig/cron/v3"
+)
+
+// ScheduleLocation is the timezone the operator evaluates schedules in.
+// The controller runs with the container default, which is UTC.
+var ScheduleLocation = time.UTC
+
+// TransitionKind distinguishes hibernation from wake transitions
+type TransitionKind string
+
+const (
+	TransitionSleep TransitionKind = "sleep"
+	TransitionWake  TransitionKind = "wake"
+)
+
+// Transition is a single scheduled hibernation or wake
+type Transition struct {
+	Kind TransitionKind
+	Time time.Time
+}
+
+// lookbackWindows are searched in order when looking for the most recent transition
+var lookbackWindows = []time.Duration{
+	8 * 24 * time.Hour,
+	32 * 24 * time.Hour,
+	367 * 24 * time.Hour,
+}
+
+// ParseSchedule parses a cron schedule with the operator's parser options
+func ParseSchedule(schedule string) (cron.Schedule, error) {
+	parser := cron.NewParser(CronParserOptions)
+	sched, err := parser.Parse(schedule)
+	if err != nil {
+		return nil, fmt.Errorf("invalid cron schedule: %w", err)
+	}
+	return sched, nil
+}
+
+// NextOccurrences returns the next n times a schedule fires after from
+func NextOccurrences(schedule string, from time.Time, n int) ([]time.Time, error) {
+	sched, err := ParseSchedule(schedule)
+	if err != nil {
+		return nil, err
+	}
+
+	var occurrences []time.Time
+	at := from.In(ScheduleLocation)
+	for len(occurrences) < n {
+		at = sched.Next(at)
+		if at.IsZero() {
+			break
+		}
+		occurrences = append(occurrences, at)
+	}
+	return occurrences, nil
+}
+
+// UpcomingTransitions returns the next n sleep and wake instants after from,
+// in chronological order. An empty wakeSchedule means the workspace is only
+// woken manually.
+func UpcomingTransitions(sleepSchedule, wakeSchedule string, from time.Time, n int) ([]Transition, error) {
+	schedules, err := parseSchedulePair(sleepSchedule, wakeSchedule)
+	if err != nil {
+		return nil, err
+	}
+
+	from = from.In(ScheduleLocation)
+	next := make(map[TransitionKind]time.Time, len(schedules))
+	for kind, sched := range schedules {
+		next[kind] = sched.Next(from)
+	}
+
+	var transitions []Transition
+	for len(transitions) < n {
+		kind, ok := earliest(next)
+		if !ok {
+			break
+		}
+		transitions = append(transitions, Transition{Kind: kind, Time: next[kind]})
+		next[kind] = schedules[kind].Next(next[kind])
+	}
+
+	return transitions, nil
+}
+
+// LastTransition returns the most recent transition at or before t. It
+// reports false if neither schedule fired within the last year.
+func LastTransition(sleepSchedule, wakeSchedule string, t time.Time) (Transition, bool, error) {
+	schedules, err := parseSchedulePair(sleepSchedule, wakeSchedule)
+	if err != nil {
+		return Transition{}, false, err
+	}
+
+	t = t.In(ScheduleLocation)
+	for _, window := range lookbackWindows {
+		var last Transition
+		found := false
+
+		for kind, sched := range schedules {
+			for at := sched.Next(t.Add(-window)); !at.IsZero() && !at.After(t); at = sched.Next(at) {
+				if !found || at.After(last.Time) || (at.Equal(last.Time) && kind == TransitionWake) {
+					last = Transition{Kind: kind, Time: at}
+					found = true
+				}
+			}
+		}
+
+		if found {
+			return last, true, nil
+		}
+	}
+
+	return Transition{}, false, nil
+}
+
+// IsAwakeAt reports whether the schedule pair leaves the workspace awake at
+// t. The second result is false when no transition happened within the last
+// year, so the state depends only on manual actions.
+func IsAwakeAt(sleepSchedule, wakeSchedule string, t time.Time) (bool, bool, error) {
+	last, ok, err := LastTransition(sleepSchedule, wakeSchedule, t)
+	if err != nil || !ok {
+		return false, false, err
+	}
+	return last.Kind == TransitionWake, true, nil
+}
+
+func parseSchedulePair(sleepSchedule, wakeSchedule string) (map[TransitionKind]cron.Schedule, error) {
+	schedules := make(map[TransitionKind]cron.Schedule, 2)
+
+	sleep, err := ParseSchedule(sleepSchedule)
+	if err != nil {
+		return nil, err
+	}
+	schedules[TransitionSleep] = sleep
+
+	if wakeSchedule != "" {
+		wake, err := ParseSchedule(wakeSchedule)
+		if err != nil {
+			return nil, err
+		}
+		schedules[TransitionWake] = wake
+	}
+
+	return schedules, nil
+}
+
+// earliest returns the kind with the earliest non-zero time. On ties sleep
+// comes first, consistent with LastTransition treating the wake as later.
+func earliest(next map[TransitionKind]time.Time) (TransitionKind, bool) {
+	kinds := make([]TransitionKind, 0, len(next))
+	for kind, at := range next {
+		if !at.IsZero() {
+			kinds = append(kinds, kind)
+		}
+	}
+	if len(kinds) == 0 {
+		return "", false
+	}
+
+	sort.Slice(kinds, func(i, j int) bool {
+		if next[kinds[i]].Equal(next[kinds[j]]) {
+			return kinds[i] == TransitionSleep
+		}
+		return next[kinds[i]].Before(next[kinds[j]])
+	})
+	return kinds[0], true
+}
@@ -0,0 +1,87 @@
+package validation
+
+import (
+	"fmt"
+	"strings"
+	"time"
+
+	// Embed the IANA database so zones resolve on hosts without zoneinfo
+	_ "time/tzdata"
+)
+
+// Timezone prefixes accepted by the cron parser in front of a schedule
+const (
+	cronTimezonePrefix   = "CRON_TZ="
+	legacyTimezonePrefix = "TZ="
+)
+
+// ValidateTimezone checks that name is an IANA timezone such as Europe/Berlin.
+// "Local" is rejected because it would resolve to the operator's zone rather
+// than the caller's.
+func ValidateTimezone(name string) (*time.Location, error) {
+	if name == "" {
+		return nil, fmt.Errorf("timezone cannot be empty")
+	}
+	if name == "Local" {
+		return nil, fmt.Errorf("timezone %q is ambiguous, use an IANA zone name such as Europe/Berlin", name)
+	}
+
+	loc, err := time.LoadLocation(name)
+	if err != nil {
+		return nil, fmt.Errorf("unknown timezone %q, use an IANA zone name such as Europe/Berlin or America/New_York", name)
+	}
+	return loc, nil
+}
+
+// hasTimezonePrefix reports whether a schedule starts with CRON_TZ= or TZ=
+func hasTimezonePrefix(schedule string) bool {
+	schedule = strings.TrimSpace(schedule)
+	return strings.HasPrefix(schedule, cronTimezonePrefix) || strings.HasPrefix(schedule, legacyTimezonePrefix)
+}
+
+// SplitScheduleTimezone separates a CRON_TZ= or TZ= prefix from a schedule.
+// The zone is empty when the schedule has no prefix.
+func SplitScheduleTimezone(schedule string) (zone, spec string) {
+	schedule = strings.TrimSpace(schedule)
+	for _, prefix := range []string{cronTimezonePrefix, legacyTimezonePrefix} {
+		if strings.HasPrefix(schedule, prefix) {
+			zone, spec, _ = strings.Cut(strings.TrimPrefix(schedule, prefix), " ")
+			return zone, strings.TrimSpace(spec)
+		}
+	}
+	return "", schedule
+}
+
+// ScheduleTimezone returns the zone a schedule is evaluated in: its own
+// prefix if present, otherwise the operator's ScheduleLocation
+func ScheduleTimezone(schedule string) string {
+	if zone, _ := SplitScheduleTimezone(schedule); zone != "" {
+		return zone
+	}
+	return ScheduleLocation.String()
+}
+
+// WithTimezone encodes zone into schedule as a CRON_TZ= prefix, replacing a
+// prefix with the same zone. A schedule that already names a different zone
+// is rejected rather than silently rewritten.
+func WithTimezone(schedule, zone string) (string, error) {
+	if _, err := ValidateTimezone(zone); err != nil {
+		return "", err
+	}
+
+	current, spec := SplitScheduleTimezone(schedule)
+	if current != "" && current != zone {
+		return "", fmt.Errorf("schedule %q already specifies timezone %s", schedule, current)
+	}
+	return cronTimezonePrefix + zone + " " + spec, nil
+}
+
+// ReplaceTimezone encodes zone into schedule, replacing any existing prefix.
+// An empty zone removes the prefix.
+func ReplaceTimezone(schedule, zone string) (string, error) {
+	_, spec := SplitScheduleTimezone(schedule)
+	if zone == "" {
+		return spec, nil
+	}
+	return WithTimezone(spec, zone)
+}
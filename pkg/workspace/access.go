@@ -0,0 +1,309 @@
+package workspace
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"strings"
+	"time"
+
+	batchv1 "github.com/forkspacer/forkspacer/api/v1"
+	authenticationv1 "k8s.io/api/authentication/v1"
+	corev1 "k8s.io/api/core/v1"
+	rbacv1 "k8s.io/api/rbac/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/controller/controllerutil"
+)
+
+// Labels put on the objects granting access to a workspace
+const (
+	// LabelWorkspace holds the name of the workspace the object grants access to
+	LabelWorkspace = "forkspacer.com/workspace"
+
+	labelManagedBy = "app.kubernetes.io/managed-by"
+	managedByCLI   = "forkspacer-cli"
+)
+
+// readVerbs and writeVerbs are granted by read-only and editing roles
+var (
+	readVerbs  = []string{"get", "list", "watch"}
+	writeVerbs = []string{"get", "list", "watch", "create", "update", "patch", "delete"}
+)
+
+// AccessScope lists the namespaces a workspace's credentials reach
+type AccessScope struct {
+	// ReleaseNamespaces are the namespaces of the modules' Helm releases
+	// in which the workloads may be accessed
+	ReleaseNamespaces []string
+
+	// Shared lists release namespaces that other workspaces use too: the
+	// workspace's own namespace, which usually holds other workspaces, and
+	// namespaces other workspaces' modules release into. No workload access
+	// is granted there.
+	Shared []string
+
+	// Templated lists release namespaces that are Go templates; only the
+	// operator knows their value
+	Templated []string
+
+	// Modules maps each namespace holding modules of the workspace to their
+	// names, which are the only modules that may be read
+	Modules map[string][]string
+}
+
+// AccessName returns the name of the ServiceAccount, Roles and RoleBindings
+// granting access to a workspace
+func AccessName(workspace *batchv1.Workspace) string {
+	return "forkspacer-" + workspace.Name
+}
+
+// AccessNamespaces returns the scope of a workspace's credentials from the
+// modules of all workspaces: the release namespaces of its own modules, less
+// those the modules of other workspaces release into
+func AccessNamespaces(workspace *batchv1.Workspace, modules []batchv1.Module) AccessScope {
+	scope := AccessScope{Modules: map[string][]string{}}
+
+	own := map[string]bool{}
+	others := map[string]bool{workspace.Namespace: true}
+	for i := range modules {
+		mod := &modules[i]
+		mine := mod.Spec.Workspace.Name == workspace.Name && mod.Spec.Workspace.Namespace == workspace.Namespace
+		if mine {
+			scope.Modules[mod.Namespace] = append(scope.Modules[mod.Namespace], mod.Name)
+		}
+		if mod.Spec.Helm == nil {
+			continue
+		}
+		namespace := mod.Spec.Helm.GetNamespace()
+		if namespace == "" {
+			continue
+		}
+		if mine {
+			own[namespace] = true
+		} else {
+			others[namespace] = true
+		}
+	}
+
+	for namespace := range own {
+		switch {
+		case strings.Contains(namespace, "{{"):
+			scope.Templated = append(scope.Templated, namespace)
+		case others[namespace]:
+			scope.Shared = append(scope.Shared, namespace)
+		default:
+			scope.ReleaseNamespaces = append(scope.ReleaseNamespaces, namespace)
+		}
+	}
+
+	sort.Strings(scope.ReleaseNamespaces)
+	sort.Strings(scope.Shared)
+	sort.Strings(scope.Templated)
+	for _, names := range scope.Modules {
+		sort.Strings(names)
+	}
+	return scope
+}
+
+// WorkspaceAccessRule allows reading the workspace itself. The workspace is
+// never writable: RBAC can not limit an update to hibernation, and its spec
+// holds the connection whose kubeconfig secret the operator reads with its
+// own cluster-wide rights.
+func WorkspaceAccessRule(workspace *batchv1.Workspace) rbacv1.PolicyRule {
+	return rbacv1.PolicyRule{
+		APIGroups:     []string{batchv1.GroupVersion.Group},
+		Resources:     []string{"workspaces"},
+		ResourceNames: []string{workspace.Name},
+		Verbs:         []string{"get", "watch"},
+	}
+}
+
+// ModuleAccessRule allows reading the named modules of a namespace. Modules
+// of other workspaces in the same namespace, and their Helm values, stay out
+// of reach; as list can not be limited to names, modules are only fetched
+// one by one.
+func ModuleAccessRule(names []string) rbacv1.PolicyRule {
+	return rbacv1.PolicyRule{
+		APIGroups:     []string{batchv1.GroupVersion.Group},
+		Resources:     []string{"modules"},
+		ResourceNames: names,
+		Verbs:         []string{"get", "watch"},
+	}
+}
+
+// ReleaseAccessRules returns the rules of the Role in each release namespace.
+// Editing access covers the modules' workloads, deleting pods to restart
+// them, exec and port-forwarding, and reading secrets; read-only access
+// leaves out secrets. Secrets and service accounts are never writable, so
+// the credentials can not mint further tokens.
+func ReleaseAccessRules(readOnly bool) []rbacv1.PolicyRule {
+	verbs := writeVerbs
+	if readOnly {
+		verbs = readVerbs
+	}
+
+	rules := []rbacv1.PolicyRule{
+		{
+			APIGroups: []string{""},
+			Resources: []string{"services", "endpoints", "configmaps", "persistentvolumeclaims"},
+			Verbs:     verbs,
+		},
+		{
+			APIGroups: []string{""},
+			Resources: []string{"pods", "pods/log", "events", "serviceaccounts"},
+			Verbs:     readVerbs,
+		},
+		{
+			APIGroups: []string{"apps"},
+			Resources: []string{"deployments", "statefulsets", "replicasets", "daemonsets"},
+			Verbs:     verbs,
+		},
+		{
+			APIGroups: []string{"batch"},
+			Resources: []string{"jobs", "cronjobs"},
+			Verbs:     verbs,
+		},
+		{
+			APIGroups: []string{"networking.k8s.io"},
+			Resources: []string{"ingresses"},
+			Verbs:     verbs,
+		},
+	}
+	if readOnly {
+		return rules
+	}
+
+	return append(rules,
+		rbacv1.PolicyRule{
+			APIGroups: []string{""},
+			Resources: []string{"pods"},
+			Verbs:     []string{"delete"},
+		},
+		rbacv1.PolicyRule{
+			APIGroups: []string{""},
+			Resources: []string{"pods/exec", "pods/portforward"},
+			Verbs:     []string{"get", "create"},
+		},
+		rbacv1.PolicyRule{
+			APIGroups: []string{""},
+			Resources: []string{"secrets"},
+			Verbs:     readVerbs,
+		},
+	)
+}
+
+// GrantAccess creates or updates the ServiceAccount of a workspace and a
+// Role and RoleBinding for it in the workspace's namespace, in each namespace
+// of its modules and in each release namespace of the scope. Objects in the
+// workspace's namespace are owned by the workspace and are garbage collected
+// with it.
+func (s *Service) GrantAccess(ctx context.Context, workspace *batchv1.Workspace, scope AccessScope, readOnly bool) (*corev1.ServiceAccount, error) {
+	name := AccessName(workspace)
+	labels := map[string]string{
+		LabelWorkspace: workspace.Name,
+		labelManagedBy: managedByCLI,
+	}
+
+	// own sets the labels of an object and, in the workspace's namespace,
+	// makes the workspace its owner
+	own := func(obj client.Object) error {
+		objLabels := obj.GetLabels()
+		if objLabels == nil {
+			objLabels = map[string]string{}
+		}
+		for k, v := range labels {
+			objLabels[k] = v
+		}
+		obj.SetLabels(objLabels)
+
+		if obj.GetNamespace() != workspace.Namespace {
+			return nil
+		}
+		return controllerutil.SetOwnerReference(workspace, obj, s.client.Scheme())
+	}
+
+	serviceAccount := &corev1.ServiceAccount{
+		ObjectMeta: metav1.ObjectMeta{Name: name, Namespace: workspace.Namespace},
+	}
+	if _, err := controllerutil.CreateOrUpdate(ctx, s.client, serviceAccount, func() error {
+		return own(serviceAccount)
+	}); err != nil {
+		return nil, fmt.Errorf("failed to apply service account %s/%s: %w", workspace.Namespace, name, err)
+	}
+
+	grant := func(namespace string, rules []rbacv1.PolicyRule) error {
+		role := &rbacv1.Role{
+			ObjectMeta: metav1.ObjectMeta{Name: name, Namespace: namespace},
+		}
+		if _, err := controllerutil.CreateOrUpdate(ctx, s.client, role, func() error {
+			role.Rules = rules
+			return own(role)
+		}); err != nil {
+			return fmt.Errorf("failed to apply role %s/%s: %w", namespace, name, err)
+		}
+
+		binding := &rbacv1.RoleBinding{
+			ObjectMeta: metav1.ObjectMeta{Name: name, Namespace: namespace},
+		}
+		if _, err := controllerutil.CreateOrUpdate(ctx, s.client, binding, func() error {
+			// The role reference is immutable, but always points at the
+			// role of the same name
+			binding.RoleRef = rbacv1.RoleRef{
+				APIGroup: rbacv1.GroupName,
+				Kind:     "Role",
+				Name:     name,
+			}
+			binding.Subjects = []rbacv1.Subject{{
+				Kind:      rbacv1.ServiceAccountKind,
+				Name:      serviceAccount.Name,
+				Namespace: serviceAccount.Namespace,
+			}}
+			return own(binding)
+		}); err != nil {
+			return fmt.Errorf("failed to apply role binding %s/%s: %w", namespace, name, err)
+		}
+		return nil
+	}
+
+	rules := map[string][]rbacv1.PolicyRule{
+		workspace.Namespace: {WorkspaceAccessRule(workspace)},
+	}
+	for namespace, names := range scope.Modules {
+		rules[namespace] = append(rules[namespace], ModuleAccessRule(names))
+	}
+	for _, namespace := range scope.ReleaseNamespaces {
+		rules[namespace] = append(rules[namespace], ReleaseAccessRules(readOnly)...)
+	}
+
+	namespaces := make([]string, 0, len(rules))
+	for namespace := range rules {
+		namespaces = append(namespaces, namespace)
+	}
+	sort.Strings(namespaces)
+	for _, namespace := range namespaces {
+		if err := grant(namespace, rules[namespace]); err != nil {
+			return nil, err
+		}
+	}
+
+	return serviceAccount, nil
+}
+
+// RequestToken requests a bound token for a ServiceAccount through the
+// TokenRequest API. The API server may issue a token with a shorter lifetime
+// than requested; the returned time is the actual expiry.
+func (s *Service) RequestToken(ctx context.Context, serviceAccount *corev1.ServiceAccount, duration time.Duration) (string, time.Time, error) {
+	seconds := int64(duration.Seconds())
+	request := &authenticationv1.TokenRequest{
+		Spec: authenticationv1.TokenRequestSpec{
+			ExpirationSeconds: &seconds,
+		},
+	}
+
+	if err := s.client.SubResource("token").Create(ctx, serviceAccount, request); err != nil {
+		return "", time.Time{}, fmt.Errorf("failed to request token for service account %s/%s: %w",
+			serviceAccount.Namespace, serviceAccount.Name, err)
+	}
+	return request.Status.Token, request.Status.ExpirationTimestamp.Time, nil
+}
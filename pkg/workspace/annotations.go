@@ -0,0 +1,148 @@
+package workspace
+
+import (
+	"encoding/json"
+	"fmt"
+	"time"
+
+	batchv1 "github.com/forkspacer/forkspacer/api/v1"
+)
+
+// Annotation keys recorded by the CLI on workspaces
+const (
+	// AnnotationWakeUntil holds the RFC 3339 time at which a temporary wake ends
+	AnnotationWakeUntil = "forkspacer.com/wake-until"
+
+	// AnnotationSnoozeUntil holds the RFC 3339 time at which a snooze of
+	// auto-hibernation ends
+	AnnotationSnoozeUntil = "forkspacer.com/snooze-until"
+
+	// AnnotationSnoozedAutoHibernation holds the JSON encoded auto-hibernation
+	// settings that were active before the workspace was snoozed
+	AnnotationSnoozedAutoHibernation = "forkspacer.com/snoozed-auto-hibernation"
+
+	// AnnotationExpiresAt holds the RFC 3339 time after which the workspace
+	// is garbage collected by 'workspace gc'
+	AnnotationExpiresAt = "forkspacer.com/expires-at"
+)
+
+// WakeUntil returns when a temporary wake of the workspace ends, if one is recorded
+func WakeUntil(workspace *batchv1.Workspace) (time.Time, bool) {
+	return annotationTime(workspace, AnnotationWakeUntil)
+}
+
+// SetWakeUntil records the end of a temporary wake on the workspace object
+func SetWakeUntil(workspace *batchv1.Workspace, until time.Time) {
+	setAnnotation(workspace, AnnotationWakeUntil, until.UTC().Format(time.RFC3339))
+}
+
+// ExpiresAt returns when the workspace expires, if it has a TTL
+func ExpiresAt(workspace *batchv1.Workspace) (time.Time, bool) {
+	return annotationTime(workspace, AnnotationExpiresAt)
+}
+
+// SetExpiresAt records the expiry of the workspace on the workspace object
+func SetExpiresAt(workspace *batchv1.Workspace, at time.Time) {
+	setAnnotation(workspace, AnnotationExpiresAt, at.UTC().Format(time.RFC3339))
+}
+
+// SnoozeUntil returns when a snooze of auto-hibernation ends, if the workspace is snoozed
+func SnoozeUntil(workspace *batchv1.Workspace) (time.Time, bool) {
+	return annotationTime(workspace, AnnotationSnoozeUntil)
+}
+
+// SnoozedAutoHibernation returns the auto-hibernation settings saved when the
+// workspace was snoozed, or nil if it is not snoozed
+func SnoozedAutoHibernation(workspace *batchv1.Workspace) (*batchv1.WorkspaceAutoHibernation, error) {
+	value, ok := workspace.Annotations[AnnotationSnoozedAutoHibernation]
+	if !ok {
+		return nil, nil
+	}
+
+	saved := &batchv1.WorkspaceAutoHibernation{}
+	if err := json.Unmarshal([]byte(value), saved); err != nil {
+		return nil, fmt.Errorf("invalid %s annotation: %w", AnnotationSnoozedAutoHibernation, err)
+	}
+	return saved, nil
+}
+
+// ApplySnooze disables auto-hibernation on the workspace object until the
+// given time, saving the current settings so they can be restored. Snoozing
+// an already snoozed workspace only moves the end time.
+func ApplySnooze(workspace *batchv1.Workspace, until time.Time) error {
+	if _, snoozed := workspace.Annotations[AnnotationSnoozedAutoHibernation]; !snoozed {
+		if workspace.Spec.AutoHibernation == nil || !workspace.Spec.AutoHibernation.Enabled {
+			return fmt.Errorf("workspace %s/%s does not have auto-hibernation enabled", workspace.Namespace, workspace.Name)
+		}
+
+		saved, err := json.Marshal(workspace.Spec.AutoHibernation)
+		if err != nil {
+			return err
+		}
+		setAnnotation(workspace, AnnotationSnoozedAutoHibernation, string(saved))
+		workspace.Spec.AutoHibernation.Enabled = false
+	}
+
+	setAnnotation(workspace, AnnotationSnoozeUntil, until.UTC().Format(time.RFC3339))
+	return nil
+}
+
+// ApplyUnsnooze restores the auto-hibernation settings saved by ApplySnooze
+func ApplyUnsnooze(workspace *batchv1.Workspace) error {
+	saved, err := SnoozedAutoHibernation(workspace)
+	if err != nil {
+		return err
+	}
+	if saved == nil {
+		return fmt.Errorf("workspace %s/%s is not snoozed", workspace.Namespace, workspace.Name)
+	}
+
+	workspace.Spec.AutoHibernation = saved
+	delete(workspace.Annotations, AnnotationSnoozeUntil)
+	delete(workspace.Annotations, AnnotationSnoozedAutoHibernation)
+	return nil
+}
+
+// ApplyAutoHibernation replaces the auto-hibernation settings of the
+// workspace object. While the workspace is snoozed, enabled settings replace
+// the saved ones so they take effect when the snooze ends; disabling ends the
+// snooze.
+func ApplyAutoHibernation(workspace *batchv1.Workspace, autoHibernation *batchv1.WorkspaceAutoHibernation) error {
+	if _, snoozed := workspace.Annotations[AnnotationSnoozedAutoHibernation]; snoozed {
+		if autoHibernation != nil && autoHibernation.Enabled {
+			saved, err := json.Marshal(autoHibernation)
+			if err != nil {
+				return err
+			}
+			setAnnotation(workspace, AnnotationSnoozedAutoHibernation, string(saved))
+			return nil
+		}
+
+		delete(workspace.Annotations, AnnotationSnoozeUntil)
+		delete(workspace.Annotations, AnnotationSnoozedAutoHibernation)
+	}
+
+	workspace.Spec.AutoHibernation = autoHibernation
+	return nil
+}
+
+// annotationTime parses an RFC 3339 timestamp stored in an annotation
+func annotationTime(workspace *batchv1.Workspace, key string) (time.Time, bool) {
+	value, ok := workspace.Annotations[key]
+	if !ok {
+		return time.Time{}, false
+	}
+
+	t, err := time.Parse(time.RFC3339, value)
+	if err != nil {
+		return time.Time{}, false
+	}
+	return t, true
+}
+
+func setAnnotation(workspace *batchv1.Workspace, key, value string) {
+	if workspace.Annotations == nil {
+		workspace.Annotations = map[string]string{}
+	}
+	workspace.Annotations[key] = value
+}
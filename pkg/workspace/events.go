@@ -0,0 +1,19 @@
+package workspace
+
+import (
+	"context"
+
+	batchv1 "github.com/forkspacer/forkspacer/api/v1"
+	corev1 "k8s.io/api/core/v1"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+)
+
+// Events returns the Kubernetes events involving a workspace
+func (s *Service) Events(ctx context.Context, workspace *batchv1.Workspace) ([]corev1.Event, error) {
+	events := &corev1.EventList{}
+	err := s.client.List(ctx, events,
+		client.InNamespace(workspace.Namespace),
+		client.MatchingFields{"involvedObject.uid": string(workspace.UID)},
+	)
+	return events.Items, err
+}
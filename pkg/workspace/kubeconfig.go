@@ -0,0 +1,82 @@
+package workspace
+
+import (
+	"errors"
+	"fmt"
+	"os"
+
+	"k8s.io/client-go/rest"
+	"k8s.io/client-go/tools/clientcmd"
+	clientcmdapi "k8s.io/client-go/tools/clientcmd/api"
+)
+
+// BuildKubeconfig returns a self-contained kubeconfig with a single context
+// that authenticates with token against the cluster of restConfig. The
+// cluster's CA is embedded so the file works on other machines.
+func BuildKubeconfig(restConfig *rest.Config, contextName, namespace, token string) (*clientcmdapi.Config, error) {
+	caData := restConfig.CAData
+	if len(caData) == 0 && restConfig.CAFile != "" {
+		data, err := os.ReadFile(restConfig.CAFile)
+		if err != nil {
+			return nil, fmt.Errorf("failed to read cluster CA: %w", err)
+		}
+		caData = data
+	}
+
+	cluster := clientcmdapi.NewCluster()
+	cluster.Server = restConfig.Host
+	cluster.CertificateAuthorityData = caData
+	cluster.InsecureSkipTLSVerify = restConfig.Insecure
+	cluster.TLSServerName = restConfig.ServerName
+
+	authInfo := clientcmdapi.NewAuthInfo()
+	authInfo.Token = token
+
+	kubeContext := clientcmdapi.NewContext()
+	kubeContext.Cluster = contextName
+	kubeContext.AuthInfo = contextName
+	kubeContext.Namespace = namespace
+
+	config := clientcmdapi.NewConfig()
+	config.Clusters[contextName] = cluster
+	config.AuthInfos[contextName] = authInfo
+	config.Contexts[contextName] = kubeContext
+	config.CurrentContext = contextName
+	return config, nil
+}
+
+// DefaultKubeconfigPath returns the kubeconfig file kubectl writes to: the
+// first file of $KUBECONFIG, or ~/.kube/config
+func DefaultKubeconfigPath() string {
+	return clientcmd.NewDefaultClientConfigLoadingRules().GetDefaultFilename()
+}
+
+// MergeKubeconfig adds the clusters, users and contexts of config to the
+// kubeconfig file at path, replacing entries with the same names. The
+// current context of the file is left unchanged unless it has none.
+func MergeKubeconfig(path string, config *clientcmdapi.Config) error {
+	existing, err := clientcmd.LoadFromFile(path)
+	if errors.Is(err, os.ErrNotExist) {
+		existing = clientcmdapi.NewConfig()
+	} else if err != nil {
+		return fmt.Errorf("failed to load kubeconfig %s: %w", path, err)
+	}
+
+	for name, cluster := range config.Clusters {
+		existing.Clusters[name] = cluster
+	}
+	for name, authInfo := range config.AuthInfos {
+		existing.AuthInfos[name] = authInfo
+	}
+	for name, kubeContext := range config.Contexts {
+		existing.Contexts[name] = kubeContext
+	}
+	if existing.CurrentContext == "" {
+		existing.CurrentContext = config.CurrentContext
+	}
+
+	if err := clientcmd.WriteToFile(*existing, path); err != nil {
+		return fmt.Errorf("failed to write kubeconfig %s: %w", path, err)
+	}
+	return nil
+}
@@ -0,0 +1,122 @@
+package workspace
+
+import (
+	"sort"
+
+	batchv1 "github.com/forkspacer/forkspacer/api/v1"
+)
+
+// LineageNode is a workspace in the fork graph with the workspaces forked from it
+type LineageNode struct {
+	Name       string `json:"name"`
+	Namespace  string `json:"namespace"`
+	Phase      string `json:"phase"`
+	Ready      bool   `json:"ready"`
+	Hibernated bool   `json:"hibernated"`
+
+	// MissingParent names the workspace this one was forked from when that
+	// workspace no longer exists
+	MissingParent string `json:"missingParent,omitempty"`
+
+	Children []*LineageNode `json:"children,omitempty"`
+}
+
+// ID returns the namespace/name key of the node
+func (n *LineageNode) ID() string {
+	return n.Namespace + "/" + n.Name
+}
+
+// Walk calls fn for the node and its descendants in depth-first order
+func (n *LineageNode) Walk(fn func(node *LineageNode, depth int)) {
+	n.walk(fn, 0)
+}
+
+func (n *LineageNode) walk(fn func(node *LineageNode, depth int), depth int) {
+	fn(n, depth)
+	for _, child := range n.Children {
+		child.walk(fn, depth+1)
+	}
+}
+
+// BuildLineage links workspaces to the workspace they were forked from and
+// returns the roots of the resulting forest, sorted by namespace and name.
+// Workspaces whose parent is missing become roots. A fork cycle, which the
+// operator cannot create, is broken by making the member that sorts last a
+// root.
+func BuildLineage(workspaces []batchv1.Workspace) []*LineageNode {
+	nodes := make(map[string]*LineageNode, len(workspaces))
+	for _, ws := range workspaces {
+		node := &LineageNode{
+			Name:       ws.Name,
+			Namespace:  ws.Namespace,
+			Phase:      string(ws.Status.Phase),
+			Ready:      ws.Status.Ready,
+			Hibernated: ws.Spec.Hibernated,
+		}
+		nodes[node.ID()] = node
+	}
+
+	sorted := make([]batchv1.Workspace, len(workspaces))
+	copy(sorted, workspaces)
+	sort.Slice(sorted, func(i, j int) bool {
+		if sorted[i].Namespace != sorted[j].Namespace {
+			return sorted[i].Namespace < sorted[j].Namespace
+		}
+		return sorted[i].Name < sorted[j].Name
+	})
+
+	parents := make(map[string]string, len(sorted))
+	var roots []*LineageNode
+	for _, ws := range sorted {
+		node := nodes[ws.Namespace+"/"+ws.Name]
+		if ws.Spec.From == nil {
+			roots = append(roots, node)
+			continue
+		}
+
+		parentNamespace := ws.Spec.From.Namespace
+		if parentNamespace == "" {
+			parentNamespace = "default"
+		}
+		parentID := parentNamespace + "/" + ws.Spec.From.Name
+
+		parent, ok := nodes[parentID]
+		if !ok {
+			node.MissingParent = parentID
+			roots = append(roots, node)
+			continue
+		}
+		if createsCycle(parents, node.ID(), parentID) {
+			roots = append(roots, node)
+			continue
+		}
+
+		parents[node.ID()] = parentID
+		parent.Children = append(parent.Children, node)
+	}
+
+	return roots
+}
+
+// createsCycle reports whether linking id to parentID closes a loop
+func createsCycle(parents map[string]string, id, parentID string) bool {
+	for current, ok := parentID, true; ok; current, ok = parents[current] {
+		if current == id {
+			return true
+		}
+	}
+	return false
+}
+
+// FindLineageNode returns the node with the given namespace and name
+func FindLineageNode(roots []*LineageNode, namespace, name string) *LineageNode {
+	var found *LineageNode
+	for _, root := range roots {
+		root.Walk(func(node *LineageNode, _ int) {
+			if found == nil && node.Namespace == namespace && node.Name == name {
+				found = node
+			}
+		})
+	}
+	return found
+}
@@ -2,18 +2,24 @@ package workspace
 
 import (
 	"context"
+	"fmt"
+	"time"
 
 	batchv1 "github.com/forkspacer/forkspacer/api/v1"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/runtime"
 	clientgoscheme "k8s.io/client-go/kubernetes/scheme"
+	"k8s.io/client-go/rest"
 	ctrl "sigs.k8s.io/controller-runtime"
 	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	"github.com/forkspacer/cli/pkg/preflight"
 )
 
 // Service provides operations for managing workspaces
 type Service struct {
-	client client.Client
+	client     client.Client
+	restConfig *rest.Config
 }
 
 // WorkspaceCreateInput defines the input for creating a workspace
@@ -24,6 +30,9 @@ type WorkspaceCreateInput struct {
 	ConnectionType  string
 	AutoHibernation *AutoHibernationInput
 	From            *FromWorkspaceInput
+
+	// ExpiresAt, if set, is recorded as the workspace expiry
+	ExpiresAt *time.Time
 }
 
 // AutoHibernationInput defines auto-hibernation configuration
@@ -39,12 +48,18 @@ type FromWorkspaceInput struct {
 	Namespace string
 }
 
+// workspaceGVK identifies the Workspace kind on objects returned to callers
+var workspaceGVK = batchv1.GroupVersion.WithKind("Workspace")
+
 // NewService creates a new workspace service
 func NewService() (*Service, error) {
 	restConfig, err := ctrl.GetConfig()
 	if err != nil {
 		return nil, err
 	}
+	if err := preflight.EnsureCRDs(restConfig); err != nil {
+		return nil, err
+	}
 
 	scheme := runtime.NewScheme()
 	if err := clientgoscheme.AddToScheme(scheme); err != nil {
@@ -60,12 +75,20 @@ func NewService() (*Service, error) {
 	}
 
 	return &Service{
-		client: k8sClient,
+		client:     k8sClient,
+		restConfig: restConfig,
 	}, nil
 }
 
-// Create creates a new workspace
-func (s *Service) Create(ctx context.Context, input WorkspaceCreateInput) (*batchv1.Workspace, error) {
+// RESTConfig returns the cluster configuration of the service, for building
+// credentials that target the same cluster
+func (s *Service) RESTConfig() *rest.Config {
+	return s.restConfig
+}
+
+// BuildWorkspace constructs the workspace object described by input without
+// sending it to the cluster
+func BuildWorkspace(input WorkspaceCreateInput) *batchv1.Workspace {
 	workspace := &batchv1.Workspace{
 		ObjectMeta: metav1.ObjectMeta{
 			Name:      input.Name,
@@ -99,12 +122,25 @@ func (s *Service) Create(ctx context.Context, input WorkspaceCreateInput) (*batc
 		}
 	}
 
-	err := s.client.Create(ctx, workspace)
+	if input.ExpiresAt != nil {
+		SetExpiresAt(workspace, *input.ExpiresAt)
+	}
+
+	workspace.SetGroupVersionKind(workspaceGVK)
+	return workspace
+}
+
+// Create creates a new workspace
+func (s *Service) Create(ctx context.Context, input WorkspaceCreateInput, opts ...client.CreateOption) (*batchv1.Workspace, error) {
+	workspace := BuildWorkspace(input)
+
+	err := s.client.Create(ctx, workspace, opts...)
+	workspace.SetGroupVersionKind(workspaceGVK)
 	return workspace, err
 }
 
 // Delete deletes a workspace
-func (s *Service) Delete(ctx context.Context, name string, namespace *string) error {
+func (s *Service) Delete(ctx context.Context, name string, namespace *string, opts ...client.DeleteOption) error {
 	ns := "default"
 	if namespace != nil {
 		ns = *namespace
@@ -117,14 +153,13 @@ func (s *Service) Delete(ctx context.Context, name string, namespace *string) er
 		},
 	}
 
-	return s.client.Delete(ctx, workspace)
+	return s.client.Delete(ctx, workspace, opts...)
 }
 
-// List workspaces with optional namespace filtering
-func (s *Service) List(ctx context.Context, namespace string) (*batchv1.WorkspaceList, error) {
+// List workspaces with optional namespace and label filtering
+func (s *Service) List(ctx context.Context, namespace string, opts ...client.ListOption) (*batchv1.WorkspaceList, error) {
 	workspaces := &batchv1.WorkspaceList{}
 
-	var opts []client.ListOption
 	if namespace != "" {
 		opts = append(opts, client.InNamespace(namespace))
 	}
@@ -140,18 +175,134 @@ func (s *Service) Get(ctx context.Context, name, namespace string) (*batchv1.Wor
 		Name:      name,
 		Namespace: namespace,
 	}, workspace)
+	workspace.SetGroupVersionKind(workspaceGVK)
 	return workspace, err
 }
 
-// SetHibernation updates the hibernation state of a workspace
-func (s *Service) SetHibernation(ctx context.Context, name, namespace string, hibernated bool) (*batchv1.Workspace, error) {
+// CheckLocal returns an error when the modules of a workspace are installed
+// in the cluster of its kubeconfig connection, where commands working on their
+// releases against this cluster would not find them
+func CheckLocal(workspace *batchv1.Workspace) error {
+	if workspace.Spec.Connection.Type == batchv1.WorkspaceConnectionTypeKubeconfig {
+		return fmt.Errorf("the modules of workspace %s are installed in the cluster of its kubeconfig connection; run the command against that cluster",
+			workspace.Name)
+	}
+	return nil
+}
+
+// SetHibernation updates the hibernation state of a workspace.
+// An explicit state change supersedes any pending temporary wake.
+func (s *Service) SetHibernation(ctx context.Context, name, namespace string, hibernated bool, opts ...client.UpdateOption) (*batchv1.Workspace, error) {
 	workspace, err := s.Get(ctx, name, namespace)
 	if err != nil {
 		return nil, err
 	}
 
 	workspace.Spec.Hibernated = hibernated
+	delete(workspace.Annotations, AnnotationWakeUntil)
+
+	err = s.client.Update(ctx, workspace, opts...)
+	workspace.SetGroupVersionKind(workspaceGVK)
+	return workspace, err
+}
+
+// WakeUntil wakes a workspace and records when the temporary wake ends so
+// that a later sweep can hibernate it again
+func (s *Service) WakeUntil(ctx context.Context, name, namespace string, until time.Time, opts ...client.UpdateOption) (*batchv1.Workspace, error) {
+	workspace, err := s.Get(ctx, name, namespace)
+	if err != nil {
+		return nil, err
+	}
+
+	workspace.Spec.Hibernated = false
+	SetWakeUntil(workspace, until)
+
+	err = s.client.Update(ctx, workspace, opts...)
+	workspace.SetGroupVersionKind(workspaceGVK)
+	return workspace, err
+}
+
+// Snooze disables auto-hibernation until the given time, remembering the
+// previous schedule in an annotation
+func (s *Service) Snooze(ctx context.Context, name, namespace string, until time.Time, opts ...client.UpdateOption) (*batchv1.Workspace, error) {
+	workspace, err := s.Get(ctx, name, namespace)
+	if err != nil {
+		return nil, err
+	}
+
+	if err := ApplySnooze(workspace, until); err != nil {
+		return nil, err
+	}
+
+	err = s.client.Update(ctx, workspace, opts...)
+	workspace.SetGroupVersionKind(workspaceGVK)
+	return workspace, err
+}
+
+// Unsnooze restores the auto-hibernation schedule saved by Snooze
+func (s *Service) Unsnooze(ctx context.Context, name, namespace string, opts ...client.UpdateOption) (*batchv1.Workspace, error) {
+	workspace, err := s.Get(ctx, name, namespace)
+	if err != nil {
+		return nil, err
+	}
+
+	if err := ApplyUnsnooze(workspace); err != nil {
+		return nil, err
+	}
+
+	err = s.client.Update(ctx, workspace, opts...)
+	workspace.SetGroupVersionKind(workspaceGVK)
+	return workspace, err
+}
+
+// SetAutoHibernation replaces the auto-hibernation settings of a workspace,
+// updating the saved settings instead while it is snoozed
+func (s *Service) SetAutoHibernation(ctx context.Context, name, namespace string, autoHibernation *batchv1.WorkspaceAutoHibernation, opts ...client.UpdateOption) (*batchv1.Workspace, error) {
+	workspace, err := s.Get(ctx, name, namespace)
+	if err != nil {
+		return nil, err
+	}
+
+	if err := ApplyAutoHibernation(workspace, autoHibernation); err != nil {
+		return nil, err
+	}
+
+	err = s.client.Update(ctx, workspace, opts...)
+	workspace.SetGroupVersionKind(workspaceGVK)
+	return workspace, err
+}
+
+// SetExpiry moves the expiry of a workspace to the given time, or removes
+// it when at is nil
+func (s *Service) SetExpiry(ctx context.Context, name, namespace string, at *time.Time, opts ...client.UpdateOption) (*batchv1.Workspace, error) {
+	workspace, err := s.Get(ctx, name, namespace)
+	if err != nil {
+		return nil, err
+	}
+
+	if at != nil {
+		SetExpiresAt(workspace, *at)
+	} else {
+		delete(workspace.Annotations, AnnotationExpiresAt)
+	}
+
+	err = s.client.Update(ctx, workspace, opts...)
+	workspace.SetGroupVersionKind(workspaceGVK)
+	return workspace, err
+}
+
+// RemoveAnnotations deletes the given annotation keys from a workspace
+func (s *Service) RemoveAnnotations(ctx context.Context, name, namespace string, keys []string, opts ...client.UpdateOption) (*batchv1.Workspace, error) {
+	workspace, err := s.Get(ctx, name, namespace)
+	if err != nil {
+		return nil, err
+	}
+
+	for _, key := range keys {
+		delete(workspace.Annotations, key)
+	}
 
-	err = s.client.Update(ctx, workspace)
+	err = s.client.Update(ctx, workspace, opts...)
+	workspace.SetGroupVersionKind(workspaceGVK)
 	return workspace, err
 }